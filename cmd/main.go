@@ -1,17 +1,28 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/authz"
 	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/events"
 	"github.com/senyabanana/tender-service/internal/handlers"
+	"github.com/senyabanana/tender-service/internal/outbox"
+	"github.com/senyabanana/tender-service/internal/pagination"
 	"github.com/senyabanana/tender-service/internal/repository"
 	"github.com/senyabanana/tender-service/internal/router"
 	"github.com/senyabanana/tender-service/internal/router/config"
+	"github.com/senyabanana/tender-service/internal/scheduler"
 	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/storage"
+	"github.com/senyabanana/tender-service/internal/webhooks"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -24,6 +35,22 @@ func main() {
 		log.Fatal("cannot load config:", err)
 	}
 
+	logger := log.New(os.Stdout, "INFO: ", log.LstdFlags)
+
+	if len(os.Args) > 1 && os.Args[1] == "relay" {
+		runOutboxRelay(cfg, logger)
+		return
+	}
+
+	if err = config.ValidateSecret("AUTH_SECRET", cfg.AuthSecret); err != nil {
+		log.Fatal(err)
+	}
+	if cfg.CursorSecret != "" {
+		if err = config.ValidateSecret("CURSOR_SECRET", cfg.CursorSecret); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	runDBMigration(cfg.MigrationURL, cfg.PostgresConn)
 
 	dbPool, err := db.InitDb(cfg)
@@ -32,18 +59,87 @@ func main() {
 	}
 	defer dbPool.Close()
 
-	logger := log.New(os.Stdout, "INFO: ", log.LstdFlags)
+	tokenIssuer := auth.NewTokenIssuer(cfg.AuthSecret, cfg.AuthTokenTTL)
 
 	tenderRepo := repository.NewPostgresTenderRepository(dbPool)
 	bidRepo := repository.NewPostgresBidRepository(dbPool)
+	organizationRepo := repository.NewPostgresOrganizationRepository(dbPool)
+	employeeRepo := repository.NewPostgresEmployeeRepository(dbPool)
+	auditRepo := repository.NewPostgresAuditRepository(dbPool)
+	evaluationRepo := repository.NewPostgresEvaluationRepository(dbPool)
+	auctionRepo := repository.NewPostgresAuctionRepository(dbPool)
+	webhookRepo := repository.NewPostgresWebhookRepository(dbPool)
+	questionRepo := repository.NewPostgresTenderQuestionRepository(dbPool)
+	var outboxRepo repository.OutboxRepository
+	if cfg.OutboxEnabled {
+		outboxRepo = repository.NewPostgresOutboxRepository(dbPool)
+	}
+
+	uow := db.NewUnitOfWork(dbPool)
+	policy := authz.NewPostgresPolicy(dbPool)
+	auditLogger := services.NewAuditLogger(auditRepo)
+
+	var eventBus events.Bus = events.NewMemoryBus()
+	if cfg.EventsBackend == "postgres" {
+		postgresBus := events.NewPostgresBus(dbPool, logger)
+		go postgresBus.Listen(context.Background())
+		eventBus = postgresBus
+	}
+	eventPublisher := services.NewEventPublisher(eventBus, webhookRepo, outboxRepo)
+
+	if cfg.AttachmentMaxSize <= 0 {
+		log.Fatal("ATTACHMENT_MAX_SIZE must be positive")
+	}
+	blobStore, err := storage.NewLocalBlobStore(cfg.AttachmentsDir)
+	if err != nil {
+		log.Fatalf("error initializing attachments storage: %v", err)
+	}
+	attachmentStorage := services.NewAttachmentStorage(blobStore, cfg.AttachmentMaxSize, cfg.AttachmentTypes)
+
+	tenderService := services.NewTenderService(tenderRepo, dbPool, uow, policy, auditLogger, eventPublisher, attachmentStorage)
+	bidService := services.NewBidService(bidRepo, tenderRepo, auctionRepo, dbPool, uow, policy, auditLogger, eventPublisher, attachmentStorage)
+	authService := services.NewAuthService(tokenIssuer, dbPool)
+	organizationService := services.NewOrganizationService(organizationRepo, dbPool, uow, policy)
+	employeeService := services.NewEmployeeService(employeeRepo, dbPool, uow)
+	auditService := services.NewAuditService(auditRepo, policy)
+	evaluationService := services.NewEvaluationService(evaluationRepo, dbPool, uow, policy, auditLogger)
+	eventService := services.NewEventService(eventBus, policy)
+	auctionService := services.NewAuctionService(auctionRepo, bidRepo, dbPool, uow, policy, auditLogger)
+	webhookService := services.NewWebhookService(webhookRepo, policy)
+	questionService := services.NewTenderQuestionService(questionRepo, uow, policy, auditLogger)
+
+	cursorSecret := cfg.CursorSecret
+	if cursorSecret == "" {
+		cursorSecret = cfg.AuthSecret
+	}
+	cursorSigner := pagination.NewSigner(cursorSecret)
 
-	tenderService := services.NewTenderService(tenderRepo, dbPool)
-	bidService := services.NewBidService(bidRepo, dbPool)
+	tenderHandler := handlers.NewTenderHandler(tenderService, logger, 5*time.Second, dbPool, cursorSigner)
+	bidHandler := handlers.NewBIdHandler(bidService, logger, 5*time.Second, dbPool, cursorSigner)
+	authHandler := handlers.NewAuthHandler(authService, logger, 5*time.Second)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService, logger, 5*time.Second)
+	employeeHandler := handlers.NewEmployeeHandler(employeeService, logger, 5*time.Second)
+	auditHandler := handlers.NewAuditHandler(auditService, logger, 5*time.Second)
+	evaluationHandler := handlers.NewEvaluationHandler(evaluationService, logger, 5*time.Second)
+	auctionHandler := handlers.NewAuctionHandler(auctionService, logger, 5*time.Second)
+	eventHandler := handlers.NewEventHandler(eventService, logger, 15*time.Second)
+	webhookHandler := handlers.NewWebhookHandler(webhookService, logger, 5*time.Second)
+	questionHandler := handlers.NewTenderQuestionHandler(questionService, logger, 5*time.Second, cursorSigner)
 
-	tenderHandler := handlers.NewTenderHandler(tenderService, logger, 5*time.Second, dbPool)
-	bidHandler := handlers.NewBIdHandler(bidService, logger, 5*time.Second, dbPool)
+	if cfg.SchedulerInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go scheduler.NewScheduler(tenderService, auctionService, uow, cfg.SchedulerInterval, logger).Run(ctx)
+	}
 
-	routes := router.InitRoutes(tenderHandler, bidHandler)
+	if cfg.WebhookInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go webhooks.NewWorker(webhookRepo, uow, cfg.WebhookInterval, logger).Run(ctx)
+	}
+
+	authMiddleware := auth.Middleware(tokenIssuer, dbPool, cfg.AuthLegacyUsername)
+	routes := router.InitRoutes(tenderHandler, bidHandler, authHandler, organizationHandler, employeeHandler, auditHandler, evaluationHandler, auctionHandler, eventHandler, webhookHandler, questionHandler, authMiddleware)
 
 	log.Printf("server is listening on %s...", cfg.ServerAddress)
 	if err := http.ListenAndServe(cfg.ServerAddress, routes); err != nil {
@@ -51,6 +147,33 @@ func main() {
 	}
 }
 
+// runOutboxRelay запускает ретранслятор outbox, отправляющий события в файл OUTBOX_SINK_FILE,
+// и блокируется до получения сигнала завершения.
+func runOutboxRelay(cfg config.Config, logger *log.Logger) {
+	if cfg.OutboxRelayInterval <= 0 {
+		log.Fatal("OUTBOX_RELAY_INTERVAL must be positive")
+	}
+
+	dbPool, err := db.InitDb(cfg)
+	if err != nil {
+		log.Fatalf("error initializing database: %v", err)
+	}
+	defer dbPool.Close()
+
+	sink, err := outbox.NewFileSink(cfg.OutboxSinkFile)
+	if err != nil {
+		log.Fatalf("cannot open outbox sink file: %v", err)
+	}
+	defer sink.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("outbox relay is writing to %s...", cfg.OutboxSinkFile)
+	outboxRepo := repository.NewPostgresOutboxRepository(dbPool)
+	outbox.NewRelay(outboxRepo, sink, db.NewUnitOfWork(dbPool), cfg.OutboxRelayInterval, logger).Run(ctx)
+}
+
 func runDBMigration(migrationURL string, dbSource string) {
 	migration, err := migrate.New(migrationURL, dbSource)
 	if err != nil {
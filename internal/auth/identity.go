@@ -0,0 +1,30 @@
+package auth
+
+import "context"
+
+type contextKey int
+
+const identityKey contextKey = iota
+
+// Identity описывает пользователя, от имени которого выполняется запрос.
+type Identity struct {
+	UserID   string
+	Username string
+}
+
+// WithIdentity возвращает контекст с информацией о вызывающем пользователе.
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+// FromContext получает информацию о вызывающем пользователе из контекста.
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey).(Identity)
+	return identity, ok
+}
+
+// Username возвращает username вызывающего пользователя или пустую строку для анонимного запроса.
+func Username(ctx context.Context) string {
+	identity, _ := FromContext(ctx)
+	return identity.Username
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/senyabanana/tender-service/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// legacyUsernameParams - параметры запроса, которыми клиенты передавали username до появления токенов.
+var legacyUsernameParams = []string{"username", "requesterUsername"}
+
+// Middleware определяет вызывающего пользователя по заголовку Authorization и сохраняет его в контексте запроса.
+// Запросы без заголовка обрабатываются как анонимные. Если включён режим совместимости (legacy),
+// пользователь без токена определяется по параметру username из строки запроса.
+func Middleware(issuer *TokenIssuer, dbPool *pgxpool.Pool, legacy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if header := r.Header.Get("Authorization"); header != "" {
+				token, found := strings.CutPrefix(header, "Bearer ")
+				if !found {
+					utils.SendErrorResponse(w, http.StatusUnauthorized, "invalid authorization header, expected Bearer token")
+					return
+				}
+
+				identity, err := issuer.Parse(token)
+				if err != nil {
+					utils.SendErrorResponse(w, http.StatusUnauthorized, err.Error())
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(WithIdentity(ctx, identity)))
+				return
+			}
+
+			if legacy {
+				if username := legacyUsername(r); username != "" {
+					userId, err := utils.GetUserIdByUsername(ctx, dbPool, username)
+					if err != nil {
+						if errors.Is(err, pgx.ErrNoRows) {
+							utils.SendErrorResponse(w, http.StatusUnauthorized, "user does not exist")
+							return
+						}
+						utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to check user existence")
+						return
+					}
+					ctx = WithIdentity(ctx, Identity{UserID: userId, Username: username})
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func legacyUsername(r *http.Request) string {
+	query := r.URL.Query()
+	for _, param := range legacyUsernameParams {
+		if username := query.Get(param); username != "" {
+			return username
+		}
+	}
+	return ""
+}
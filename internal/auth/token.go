@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// tokenHeader - заголовок JWT, подписанного HMAC-SHA256.
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims - полезная нагрузка токена доступа.
+type Claims struct {
+	Subject   string `json:"sub"`
+	Username  string `json:"username"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// TokenIssuer выпускает и проверяет токены доступа в формате JWT (HS256).
+type TokenIssuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTokenIssuer создаёт новый экземпляр TokenIssuer.
+func NewTokenIssuer(secret string, ttl time.Duration) *TokenIssuer {
+	return &TokenIssuer{secret: []byte(secret), ttl: ttl}
+}
+
+// Issue выпускает токен для пользователя и возвращает его вместе со временем истечения.
+func (i *TokenIssuer) Issue(identity Identity) (string, time.Time, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(i.ttl)
+	claims := Claims{
+		Subject:   identity.UserID,
+		Username:  identity.Username,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + i.sign(unsigned), expiresAt, nil
+}
+
+// Parse проверяет подпись и срок действия токена и возвращает пользователя, которому он выпущен.
+func (i *TokenIssuer) Parse(token string) (Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return Identity{}, ErrInvalidToken
+	}
+
+	expected := i.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return Identity{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Identity{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err = json.Unmarshal(payload, &claims); err != nil || claims.Subject == "" || claims.Username == "" {
+		return Identity{}, ErrInvalidToken
+	}
+	if time.Now().UTC().Unix() >= claims.ExpiresAt {
+		return Identity{}, ErrTokenExpired
+	}
+
+	return Identity{UserID: claims.Subject, Username: claims.Username}, nil
+}
+
+func (i *TokenIssuer) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,152 @@
+package authz
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryTender - сведения о тендере, необходимые для проверки прав.
+type memoryTender struct {
+	OrganizationID  string
+	CreatorUsername string
+	Status          string
+	Sealed          bool
+}
+
+// memoryBid - сведения о предложении, необходимые для проверки прав.
+type memoryBid struct {
+	TenderID   string
+	AuthorID   string
+	AuthorType string
+	Status     string
+}
+
+// MemoryPolicy - реализация Policy, хранящая сведения о ресурсах в памяти. Предназначена для тестов.
+type MemoryPolicy struct {
+	mu           sync.RWMutex
+	responsibles map[string]map[string]bool // организация -> id ответственных
+	tenders      map[string]memoryTender
+	bids         map[string]memoryBid
+}
+
+// NewMemoryPolicy создаёт новый экземпляр MemoryPolicy без ресурсов.
+func NewMemoryPolicy() *MemoryPolicy {
+	return &MemoryPolicy{
+		responsibles: make(map[string]map[string]bool),
+		tenders:      make(map[string]memoryTender),
+		bids:         make(map[string]memoryBid),
+	}
+}
+
+// AddOrganization регистрирует организацию вместе с её ответственными.
+func (p *MemoryPolicy) AddOrganization(organizationId string, responsibleIds ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.responsibles[organizationId] == nil {
+		p.responsibles[organizationId] = make(map[string]bool)
+	}
+	for _, userId := range responsibleIds {
+		p.responsibles[organizationId][userId] = true
+	}
+}
+
+// PutTender регистрирует тендер или обновляет его статус.
+func (p *MemoryPolicy) PutTender(tenderId, organizationId, creatorUsername, status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tenders[tenderId] = memoryTender{OrganizationID: organizationId, CreatorUsername: creatorUsername, Status: status}
+}
+
+// SealTender включает или выключает для тендера закрытый режим до вскрытия предложений.
+func (p *MemoryPolicy) SealTender(tenderId string, sealed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if tender, ok := p.tenders[tenderId]; ok {
+		tender.Sealed = sealed
+		p.tenders[tenderId] = tender
+	}
+}
+
+// PutBid регистрирует предложение или обновляет его статус.
+func (p *MemoryPolicy) PutBid(bidId, tenderId, authorId, authorType, status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bids[bidId] = memoryBid{TenderID: tenderId, AuthorID: authorId, AuthorType: authorType, Status: status}
+}
+
+// Can сообщает, может ли пользователь выполнить действие над ресурсом.
+func (p *MemoryPolicy) Can(_ context.Context, subject Subject, action Action, resource Resource) (bool, error) {
+	if !appliesTo(action, resource) {
+		return false, nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var rel Relations
+	switch resource.Type {
+	case OrganizationResourceType:
+		responsibles, ok := p.responsibles[resource.ID]
+		if !ok {
+			return false, ErrResourceNotFound
+		}
+		rel.Responsible = responsibles[subject.UserID]
+	case TenderResourceType:
+		tender, ok := p.tenders[resource.ID]
+		if !ok {
+			return false, ErrResourceNotFound
+		}
+		rel = p.tenderRelations(subject, tender)
+	case BidResourceType:
+		bid, ok := p.bids[resource.ID]
+		if !ok {
+			return false, ErrResourceNotFound
+		}
+		tender, ok := p.tenders[bid.TenderID]
+		if !ok {
+			return false, ErrResourceNotFound
+		}
+		rel.Status = bid.Status
+		rel.Creator = subject.UserID != "" && bid.AuthorID == subject.UserID
+		rel.CreatorOrganization = bid.AuthorType == organizationAuthor && p.sameOrganization(bid.AuthorID, subject.UserID)
+		rel.Responsible = p.responsibles[tender.OrganizationID][subject.UserID]
+		rel.Sealed = tender.Sealed
+	case AuditResourceType:
+		rel.Responsible = p.responsibleAnywhere(subject.UserID)
+	}
+	return Decide(action, rel), nil
+}
+
+// tenderRelations определяет отношения пользователя к тендеру.
+func (p *MemoryPolicy) tenderRelations(subject Subject, tender memoryTender) Relations {
+	return Relations{
+		Status:      tender.Status,
+		Creator:     subject.Username != "" && tender.CreatorUsername == subject.Username,
+		Responsible: p.responsibles[tender.OrganizationID][subject.UserID],
+		Sealed:      tender.Sealed,
+	}
+}
+
+// responsibleAnywhere сообщает, является ли пользователь ответственным хотя бы одной организации.
+func (p *MemoryPolicy) responsibleAnywhere(userId string) bool {
+	for _, responsibles := range p.responsibles {
+		if responsibles[userId] {
+			return true
+		}
+	}
+	return false
+}
+
+// sameOrganization сообщает, являются ли два пользователя ответственными одной организации.
+func (p *MemoryPolicy) sameOrganization(userId, otherUserId string) bool {
+	if userId == "" || otherUserId == "" {
+		return false
+	}
+	for _, responsibles := range p.responsibles {
+		if responsibles[userId] && responsibles[otherUserId] {
+			return true
+		}
+	}
+	return false
+}
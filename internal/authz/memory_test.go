@@ -0,0 +1,101 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryPolicy(t *testing.T) {
+	const (
+		tenderOrg    = "org-tender"
+		bidderOrg    = "org-bidder"
+		responsible  = "user-responsible"
+		bidder       = "user-bidder"
+		bidderPeer   = "user-bidder-peer"
+		outsider     = "user-outsider"
+		openTender   = "tender-open"
+		sealedTender = "tender-sealed"
+		openBid      = "bid-open"
+		draftBid     = "bid-draft"
+		sealedBid    = "bid-sealed"
+	)
+
+	policy := NewMemoryPolicy()
+	policy.AddOrganization(tenderOrg, responsible)
+	policy.AddOrganization(bidderOrg, bidder, bidderPeer)
+	policy.PutTender(openTender, tenderOrg, "creator", publishedStatus)
+	policy.PutTender(sealedTender, tenderOrg, "creator", publishedStatus)
+	policy.SealTender(sealedTender, true)
+	policy.PutBid(openBid, openTender, bidder, organizationAuthor, publishedStatus)
+	policy.PutBid(draftBid, openTender, bidder, organizationAuthor, "Created")
+	policy.PutBid(sealedBid, sealedTender, bidder, "User", publishedStatus)
+
+	user := func(id string) Subject { return Subject{UserID: id} }
+
+	tests := []struct {
+		name     string
+		subject  Subject
+		action   Action
+		resource Resource
+		want     bool
+	}{
+		{"responsible manages organization", user(responsible), OrganizationManage, OrganizationResource(tenderOrg), true},
+		{"outsider cannot manage organization", user(outsider), OrganizationManage, OrganizationResource(tenderOrg), false},
+		{"anyone reads published tender", Subject{}, TenderRead, TenderResource(openTender), true},
+		{"creator edits tender", Subject{Username: "creator"}, TenderEdit, TenderResource(openTender), true},
+		{"outsider cannot edit tender", user(outsider), TenderEdit, TenderResource(openTender), false},
+
+		{"author reads own draft", user(bidder), BidRead, BidResource(draftBid), true},
+		{"author organization peer edits bid", user(bidderPeer), BidEdit, BidResource(openBid), true},
+		{"responsible reads published bid", user(responsible), BidRead, BidResource(openBid), true},
+		{"responsible cannot read draft bid", user(responsible), BidRead, BidResource(draftBid), false},
+		{"responsible cannot read sealed bid", user(responsible), BidRead, BidResource(sealedBid), false},
+		{"peer of user-authored bid cannot read it", user(bidderPeer), BidRead, BidResource(sealedBid), false},
+		{"responsible decides published bid", user(responsible), BidDecide, BidResource(openBid), true},
+		{"responsible cannot decide sealed bid", user(responsible), BidDecide, BidResource(sealedBid), false},
+
+		{"responsible negotiates draft bid", user(responsible), BidNegotiate, BidResource(draftBid), true},
+		{"responsible cannot negotiate sealed bid", user(responsible), BidNegotiate, BidResource(sealedBid), false},
+		{"outsider cannot negotiate", user(outsider), BidNegotiate, BidResource(openBid), false},
+
+		{"responsible reads audit", user(responsible), AuditRead, AuditResource(), true},
+		{"outsider cannot read audit", user(outsider), AuditRead, AuditResource(), false},
+
+		{"action does not apply to resource type", user(responsible), BidRead, TenderResource(openTender), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.Can(context.Background(), tt.subject, tt.action, tt.resource)
+			if err != nil {
+				t.Fatalf("Can() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Can() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("bids become visible after opening", func(t *testing.T) {
+		policy.SealTender(sealedTender, false)
+		defer policy.SealTender(sealedTender, true)
+		got, err := policy.Can(context.Background(), user(responsible), BidRead, BidResource(sealedBid))
+		if err != nil || !got {
+			t.Errorf("Can() = %v, %v, want true, nil", got, err)
+		}
+	})
+
+	t.Run("missing resource", func(t *testing.T) {
+		for _, resource := range []Resource{OrganizationResource("missing"), TenderResource("missing"), BidResource("missing")} {
+			action := map[ResourceType]Action{
+				OrganizationResourceType: OrganizationManage,
+				TenderResourceType:       TenderRead,
+				BidResourceType:          BidRead,
+			}[resource.Type]
+			if _, err := policy.Can(context.Background(), user(responsible), action, resource); !errors.Is(err, ErrResourceNotFound) {
+				t.Errorf("Can(%s) error = %v, want ErrResourceNotFound", resource.Type, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package authz
+
+import (
+	"context"
+	"errors"
+)
+
+// Action - действие, право на которое проверяет политика доступа.
+type Action string
+
+const (
+	OrganizationManage Action = "organization.manage" // Изменение организации и её ответственных
+
+	TenderCreate      Action = "tender.create"       // Создание тендера от имени организации
+	TenderRead        Action = "tender.read"         // Просмотр тендера и его статуса
+	TenderEdit        Action = "tender.edit"         // Редактирование тендера
+	TenderPublish     Action = "tender.publish"      // Смена статуса тендера: публикация и закрытие
+	TenderRollback    Action = "tender.rollback"     // Откат версии тендера
+	TenderHistoryRead Action = "tender.history.read" // Просмотр истории версий тендера
+
+	BidCreate      Action = "bid.create"       // Создание предложения по тендеру
+	BidRead        Action = "bid.read"         // Просмотр предложения и его статуса
+	BidEdit        Action = "bid.edit"         // Редактирование и смена статуса предложения
+	BidRollback    Action = "bid.rollback"     // Откат версии предложения
+	BidHistoryRead Action = "bid.history.read" // Просмотр истории версий предложения
+	BidDecide      Action = "bid.decide"       // Решение по предложению
+	BidScore       Action = "bid.score"        // Оценка предложения по критериям тендера
+	BidNegotiate   Action = "bid.negotiate"    // Переписка по предложению
+
+	ReviewCreate Action = "review.create" // Отзыв на предложение
+	ReviewRead   Action = "review.read"   // Просмотр отзывов на предложения по тендеру
+
+	EvaluationRead Action = "evaluation.read" // Просмотр сравнительной оценки предложений по тендеру
+
+	QuestionAsk    Action = "question.ask"    // Вопрос по тендеру
+	QuestionAnswer Action = "question.answer" // Ответ на вопрос по тендеру
+
+	AuditRead Action = "audit.read" // Просмотр журнала аудита
+)
+
+// ResourceType - тип ресурса, к которому запрашивается доступ.
+type ResourceType string
+
+const (
+	OrganizationResourceType ResourceType = "organization"
+	TenderResourceType       ResourceType = "tender"
+	BidResourceType          ResourceType = "bid"
+	AuditResourceType        ResourceType = "audit"
+)
+
+// ErrResourceNotFound возвращается, если ресурс, к которому запрашивается доступ, не существует.
+var ErrResourceNotFound = errors.New("resource not found")
+
+// Subject описывает пользователя, для которого проверяется право. Пустой Subject - анонимный пользователь.
+type Subject struct {
+	UserID   string
+	Username string
+}
+
+// Anonymous сообщает, является ли пользователь анонимным.
+func (s Subject) Anonymous() bool {
+	return s.UserID == "" && s.Username == ""
+}
+
+// Resource - ресурс, к которому запрашивается доступ.
+type Resource struct {
+	Type ResourceType
+	ID   string
+}
+
+// OrganizationResource возвращает ресурс организации.
+func OrganizationResource(organizationId string) Resource {
+	return Resource{Type: OrganizationResourceType, ID: organizationId}
+}
+
+// TenderResource возвращает ресурс тендера.
+func TenderResource(tenderId string) Resource {
+	return Resource{Type: TenderResourceType, ID: tenderId}
+}
+
+// BidResource возвращает ресурс предложения.
+func BidResource(bidId string) Resource {
+	return Resource{Type: BidResourceType, ID: bidId}
+}
+
+// AuditResource возвращает ресурс журнала аудита. Журнал не относится к отдельной организации:
+// каждый ответственный видит в нём события своих организаций.
+func AuditResource() Resource {
+	return Resource{Type: AuditResourceType}
+}
+
+// Policy - интерфейс политики доступа.
+type Policy interface {
+	// Can сообщает, может ли пользователь выполнить действие над ресурсом.
+	// Если ресурс не существует, возвращает ErrResourceNotFound.
+	Can(ctx context.Context, subject Subject, action Action, resource Resource) (bool, error)
+}
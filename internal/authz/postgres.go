@@ -0,0 +1,137 @@
+package authz
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPolicy - реализация Policy, определяющая отношения пользователя к ресурсам по базе данных.
+type PostgresPolicy struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresPolicy создаёт новый экземпляр PostgresPolicy.
+func NewPostgresPolicy(db *pgxpool.Pool) *PostgresPolicy {
+	return &PostgresPolicy{DB: db}
+}
+
+// Can сообщает, может ли пользователь выполнить действие над ресурсом.
+func (p *PostgresPolicy) Can(ctx context.Context, subject Subject, action Action, resource Resource) (bool, error) {
+	if !appliesTo(action, resource) {
+		return false, nil
+	}
+	if _, err := uuid.Parse(resource.ID); err != nil && resource.Type != AuditResourceType {
+		return false, ErrResourceNotFound
+	}
+
+	subject, err := p.resolveSubject(ctx, subject)
+	if err != nil {
+		return false, err
+	}
+
+	var rel Relations
+	switch resource.Type {
+	case OrganizationResourceType:
+		rel, err = p.organizationRelations(ctx, subject, resource.ID)
+	case TenderResourceType:
+		rel, err = p.tenderRelations(ctx, subject, resource.ID)
+	case BidResourceType:
+		rel, err = p.bidRelations(ctx, subject, resource.ID)
+	case AuditResourceType:
+		rel, err = p.auditRelations(ctx, subject)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrResourceNotFound
+		}
+		return false, err
+	}
+	return Decide(action, rel), nil
+}
+
+// resolveSubject дополняет данные пользователя по базе: пользователь из режима совместимости известен
+// только по username. Несуществующий пользователь считается анонимным.
+func (p *PostgresPolicy) resolveSubject(ctx context.Context, subject Subject) (Subject, error) {
+	if subject.Anonymous() {
+		return subject, nil
+	}
+
+	var resolved Subject
+	query := `SELECT id, username FROM employee WHERE id::text = $1 OR username = $2 LIMIT 1`
+	err := p.DB.QueryRow(ctx, query, subject.UserID, subject.Username).Scan(&resolved.UserID, &resolved.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Subject{}, nil
+		}
+		return Subject{}, err
+	}
+	return resolved, nil
+}
+
+// organizationRelations определяет отношения пользователя к организации.
+func (p *PostgresPolicy) organizationRelations(ctx context.Context, subject Subject, organizationId string) (Relations, error) {
+	var rel Relations
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM organization_responsible
+			WHERE organization_id = o.id AND user_id::text = $2
+		)
+		FROM organization o
+		WHERE o.id = $1`
+	err := p.DB.QueryRow(ctx, query, organizationId, subject.UserID).Scan(&rel.Responsible)
+	return rel, err
+}
+
+// auditRelations определяет отношения пользователя к журналу аудита.
+func (p *PostgresPolicy) auditRelations(ctx context.Context, subject Subject) (Relations, error) {
+	var rel Relations
+	query := `SELECT EXISTS(SELECT 1 FROM organization_responsible WHERE user_id::text = $1)`
+	err := p.DB.QueryRow(ctx, query, subject.UserID).Scan(&rel.Responsible)
+	return rel, err
+}
+
+// tenderRelations определяет отношения пользователя к тендеру.
+func (p *PostgresPolicy) tenderRelations(ctx context.Context, subject Subject, tenderId string) (Relations, error) {
+	var rel Relations
+	query := `
+		SELECT t.status,
+		       t.creator_username = $3,
+		       EXISTS(
+		           SELECT 1 FROM organization_responsible
+		           WHERE organization_id = t.organization_id AND user_id::text = $2
+		       ),
+		       ` + SealedCondition + `
+		FROM tender t
+		WHERE t.id = $1`
+	err := p.DB.QueryRow(ctx, query, tenderId, subject.UserID, subject.Username).
+		Scan(&rel.Status, &rel.Creator, &rel.Responsible, &rel.Sealed)
+	return rel, err
+}
+
+// bidRelations определяет отношения пользователя к предложению.
+func (p *PostgresPolicy) bidRelations(ctx context.Context, subject Subject, bidId string) (Relations, error) {
+	var rel Relations
+	query := `
+		SELECT b.status,
+		       b.author_id::text = $2,
+		       b.author_type = $3 AND EXISTS(
+		           SELECT 1 FROM organization_responsible a
+		           JOIN organization_responsible v ON a.organization_id = v.organization_id
+		           WHERE a.user_id = b.author_id AND v.user_id::text = $2
+		       ),
+		       EXISTS(
+		           SELECT 1 FROM organization_responsible
+		           WHERE organization_id = t.organization_id AND user_id::text = $2
+		       ),
+		       ` + SealedCondition + `
+		FROM bid b
+		JOIN tender t ON b.tender_id = t.id
+		WHERE b.id = $1`
+	err := p.DB.QueryRow(ctx, query, bidId, subject.UserID, organizationAuthor).
+		Scan(&rel.Status, &rel.Creator, &rel.CreatorOrganization, &rel.Responsible, &rel.Sealed)
+	return rel, err
+}
@@ -0,0 +1,88 @@
+package authz
+
+const (
+	publishedStatus    = "Published"    // Статус опубликованного тендера или предложения
+	organizationAuthor = "Organization" // Тип автора предложения от имени организации
+)
+
+// SealedCondition - SQL-условие нахождения тендера t в закрытом режиме до вскрытия предложений,
+// см. models.Tender.BidsSealed. Используется и политикой доступа, и запросами списков, чтобы правила совпадали.
+const SealedCondition = `(t.sealed AND t.bids_opened_at IS NULL AND t.status <> 'Closed'
+	AND (t.bids_opening_at IS NULL OR t.bids_opening_at > now() AT TIME ZONE 'UTC'))`
+
+// Relations - отношения пользователя к ресурсу, на основании которых принимается решение.
+type Relations struct {
+	// Responsible - пользователь ответственный за организацию ресурса: саму организацию,
+	// организацию тендера или организацию тендера, по которому подано предложение.
+	// Для журнала аудита - пользователь ответственный хотя бы за одну организацию.
+	Responsible bool
+	// Creator - пользователь создал тендер или является автором предложения.
+	Creator bool
+	// CreatorOrganization - пользователь ответственный организации, от имени которой подано предложение.
+	CreatorOrganization bool
+	// Status - текущий статус тендера или предложения.
+	Status string
+	// Sealed - тендер ресурса находится в закрытом режиме, и предложения по нему ещё не вскрыты.
+	Sealed bool
+}
+
+// Decide определяет по матрице доступа, разрешено ли действие при данных отношениях пользователя к ресурсу.
+func Decide(action Action, rel Relations) bool {
+	switch action {
+	case OrganizationManage, TenderCreate, ReviewRead, AuditRead:
+		return rel.Responsible
+	case EvaluationRead:
+		return rel.Responsible && !rel.Sealed
+	case TenderRead:
+		return rel.Status == publishedStatus || rel.Responsible || rel.Creator
+	case TenderEdit, TenderPublish, TenderRollback, TenderHistoryRead:
+		return rel.Responsible || rel.Creator
+	case BidCreate:
+		return true
+	case BidRead:
+		return rel.Creator || rel.CreatorOrganization || (rel.Responsible && rel.Status == publishedStatus && !rel.Sealed)
+	case BidNegotiate:
+		return rel.Creator || rel.CreatorOrganization || (rel.Responsible && !rel.Sealed)
+	case BidEdit, BidRollback, BidHistoryRead:
+		return rel.Creator || rel.CreatorOrganization
+	case QuestionAsk:
+		return rel.Status == publishedStatus
+	case QuestionAnswer:
+		return rel.Responsible
+	case BidDecide, BidScore, ReviewCreate:
+		return rel.Responsible && rel.Status == publishedStatus && !rel.Sealed
+	default:
+		return false
+	}
+}
+
+// resourceTypes - тип ресурса, над которым выполняется каждое действие.
+var resourceTypes = map[Action]ResourceType{
+	OrganizationManage: OrganizationResourceType,
+	TenderCreate:       OrganizationResourceType,
+	TenderRead:         TenderResourceType,
+	TenderEdit:         TenderResourceType,
+	TenderPublish:      TenderResourceType,
+	TenderRollback:     TenderResourceType,
+	TenderHistoryRead:  TenderResourceType,
+	BidCreate:          TenderResourceType,
+	BidRead:            BidResourceType,
+	BidEdit:            BidResourceType,
+	BidRollback:        BidResourceType,
+	BidHistoryRead:     BidResourceType,
+	BidDecide:          BidResourceType,
+	BidScore:           BidResourceType,
+	BidNegotiate:       BidResourceType,
+	ReviewCreate:       BidResourceType,
+	ReviewRead:         TenderResourceType,
+	EvaluationRead:     TenderResourceType,
+	QuestionAsk:        TenderResourceType,
+	QuestionAnswer:     TenderResourceType,
+	AuditRead:          AuditResourceType,
+}
+
+// appliesTo сообщает, применимо ли действие к ресурсу данного типа.
+func appliesTo(action Action, resource Resource) bool {
+	resourceType, ok := resourceTypes[action]
+	return ok && resourceType == resource.Type
+}
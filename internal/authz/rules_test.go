@@ -0,0 +1,108 @@
+package authz
+
+import "testing"
+
+func TestDecide(t *testing.T) {
+	var (
+		anonymous          = Relations{}
+		responsible        = Relations{Responsible: true}
+		creator            = Relations{Creator: true}
+		creatorOrg         = Relations{CreatorOrganization: true}
+		published          = Relations{Status: publishedStatus}
+		publishedResp      = Relations{Responsible: true, Status: publishedStatus}
+		sealedResp         = Relations{Responsible: true, Status: publishedStatus, Sealed: true}
+		closedResp         = Relations{Responsible: true, Status: "Closed"}
+		createdResp        = Relations{Responsible: true, Status: "Created"}
+		sealedCreatedResp  = Relations{Responsible: true, Status: "Created", Sealed: true}
+		approvedResp       = Relations{Responsible: true, Status: "Approved"}
+		sealedCreator      = Relations{Creator: true, Status: publishedStatus, Sealed: true}
+		sealedCreatorOrg   = Relations{CreatorOrganization: true, Status: "Created", Sealed: true}
+		publishedAnonymous = Relations{Status: publishedStatus, Sealed: true}
+	)
+
+	tests := []struct {
+		action Action
+		rel    Relations
+		want   bool
+	}{
+		{OrganizationManage, responsible, true},
+		{OrganizationManage, creator, false},
+		{TenderCreate, responsible, true},
+		{TenderCreate, anonymous, false},
+		{ReviewRead, responsible, true},
+		{ReviewRead, creatorOrg, false},
+		{AuditRead, responsible, true},
+		{AuditRead, anonymous, false},
+
+		{EvaluationRead, publishedResp, true},
+		{EvaluationRead, sealedResp, false},
+
+		{TenderRead, published, true},
+		{TenderRead, createdResp, true},
+		{TenderRead, creator, true},
+		{TenderRead, anonymous, false},
+		{TenderEdit, responsible, true},
+		{TenderEdit, creator, true},
+		{TenderEdit, published, false},
+		{TenderPublish, creator, true},
+		{TenderRollback, responsible, true},
+		{TenderHistoryRead, published, false},
+
+		{BidCreate, anonymous, true},
+
+		{BidRead, creator, true},
+		{BidRead, creatorOrg, true},
+		{BidRead, sealedCreator, true},
+		{BidRead, publishedResp, true},
+		{BidRead, createdResp, false},
+		{BidRead, sealedResp, false},
+		{BidRead, publishedAnonymous, false},
+		{BidEdit, creator, true},
+		{BidEdit, creatorOrg, true},
+		{BidEdit, publishedResp, false},
+		{BidRollback, publishedResp, false},
+		{BidHistoryRead, creatorOrg, true},
+
+		{BidNegotiate, sealedCreator, true},
+		{BidNegotiate, sealedCreatorOrg, true},
+		{BidNegotiate, publishedResp, true},
+		{BidNegotiate, approvedResp, true},
+		{BidNegotiate, createdResp, true},
+		{BidNegotiate, sealedResp, false},
+		{BidNegotiate, sealedCreatedResp, false},
+		{BidNegotiate, publishedAnonymous, false},
+
+		{BidDecide, publishedResp, true},
+		{BidDecide, sealedResp, false},
+		{BidDecide, closedResp, false},
+		{BidDecide, creator, false},
+		{BidScore, publishedResp, true},
+		{ReviewCreate, sealedResp, false},
+
+		{QuestionAsk, published, true},
+		{QuestionAsk, closedResp, false},
+		{QuestionAnswer, responsible, true},
+		{QuestionAnswer, published, false},
+
+		{Action("unknown"), responsible, false},
+	}
+
+	for _, tt := range tests {
+		if got := Decide(tt.action, tt.rel); got != tt.want {
+			t.Errorf("Decide(%s, %+v) = %v, want %v", tt.action, tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestEveryDecidedActionHasResourceType(t *testing.T) {
+	actions := []Action{
+		OrganizationManage, TenderCreate, TenderRead, TenderEdit, TenderPublish, TenderRollback, TenderHistoryRead,
+		BidCreate, BidRead, BidEdit, BidRollback, BidHistoryRead, BidDecide, BidScore, BidNegotiate,
+		ReviewCreate, ReviewRead, EvaluationRead, QuestionAsk, QuestionAnswer, AuditRead,
+	}
+	for _, action := range actions {
+		if _, ok := resourceTypes[action]; !ok {
+			t.Errorf("action %s has no resource type", action)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DBTX - общий интерфейс пула соединений и транзакции, которым пользуются репозитории.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+type afterCommitKey struct{}
+
+// UnitOfWork объединяет несколько операций репозиториев в одну транзакцию.
+type UnitOfWork struct {
+	pool *pgxpool.Pool
+}
+
+// NewUnitOfWork создаёт новый экземпляр UnitOfWork.
+func NewUnitOfWork(pool *pgxpool.Pool) *UnitOfWork {
+	return &UnitOfWork{pool: pool}
+}
+
+// WithinTx выполняет fn в транзакции, которая передаётся репозиториям через контекст.
+// Транзакция фиксируется, если fn завершилась без ошибки, и откатывается в противном случае.
+// Если в контексте уже есть транзакция, fn выполняется в ней.
+// После фиксации выполняются функции, зарегистрированные внутри fn через AfterCommit.
+func (u *UnitOfWork) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var hooks []func()
+	txCtx := context.WithValue(context.WithValue(ctx, txKey{}, tx), afterCommitKey{}, &hooks)
+	if err = fn(txCtx); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		hook()
+	}
+	return nil
+}
+
+// AfterCommit откладывает выполнение fn до фиксации транзакции из контекста.
+// Если транзакции нет, fn выполняется сразу; при откате транзакции fn не выполняется.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		*hooks = append(*hooks, fn)
+		return
+	}
+	fn()
+}
+
+// Conn возвращает транзакцию из контекста, а если её нет - пул соединений.
+func Conn(ctx context.Context, pool *pgxpool.Pool) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// TryAdvisoryLock пытается взять транзакционную advisory-блокировку PostgreSQL с ключом key.
+// Блокировка снимается при завершении транзакции, поэтому метод следует вызывать внутри WithinTx.
+// Возвращает false, если блокировку уже держит другой сеанс.
+func (u *UnitOfWork) TryAdvisoryLock(ctx context.Context, key int64) (bool, error) {
+	var acquired bool
+	err := Conn(ctx, u.pool).QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, key).Scan(&acquired)
+	return acquired, err
+}
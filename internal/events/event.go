@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Type string // Тип события об изменении тендера или предложения
+
+const (
+	TenderPublished Type = "tender.published" // Тендер опубликован
+	TenderClosed    Type = "tender.closed"    // Тендер закрыт
+	TenderEdited    Type = "tender.edited"    // Тендер отредактирован, откачен или изменены его лоты или вложения
+	BidCreated      Type = "bid.created"      // Предложение создано
+	BidPublished    Type = "bid.published"    // Предложение опубликовано
+	BidDecided      Type = "bid.decided"      // Принято решение по предложению
+	BidFeedback     Type = "bid.feedback"     // Оставлен отзыв на предложение
+)
+
+// Types - все типы событий в порядке объявления.
+var Types = []Type{TenderPublished, TenderClosed, TenderEdited, BidCreated, BidPublished, BidDecided, BidFeedback}
+
+// IsKnown сообщает, является ли t одним из типов событий сервиса.
+func (t Type) IsKnown() bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event представляет уведомление об изменении тендера или предложения. Событие содержит только
+// идентификаторы и статус: актуальное состояние клиент получает обычными запросами к API.
+type Event struct {
+	ID         string    `json:"id"`
+	Type       Type      `json:"type"`
+	TenderID   string    `json:"tenderId"`
+	BidID      string    `json:"bidId,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Version    int       `json:"version,omitempty"`
+	Actor      string    `json:"actor,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// IsBidEvent сообщает, относится ли событие к предложению.
+func (e Event) IsBidEvent() bool {
+	return e.BidID != ""
+}
+
+// NewTenderEvent создаёт событие об изменении тендера.
+func NewTenderEvent(eventType Type, tenderId, status string, version int) Event {
+	return Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		TenderID:   tenderId,
+		Status:     status,
+		Version:    version,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// NewBidEvent создаёт событие об изменении предложения.
+func NewBidEvent(eventType Type, tenderId, bidId, status string, version int) Event {
+	event := NewTenderEvent(eventType, tenderId, status, version)
+	event.BidID = bidId
+	return event
+}
+
+// Bus - шина событий, в которую сервисы публикуют изменения и из которой читают подписчики.
+type Bus interface {
+	// Publish публикует событие. Если в контексте есть транзакция, подписчики получат событие
+	// только после её фиксации.
+	Publish(ctx context.Context, event Event)
+	// Subscribe создаёт подписку на все последующие события.
+	Subscribe() *Subscription
+}
+
+// Subscription - подписка на события шины. Подписку необходимо закрыть методом Close.
+type Subscription struct {
+	C      <-chan Event
+	cancel func()
+}
+
+// Close отменяет подписку и закрывает канал C.
+func (s *Subscription) Close() {
+	s.cancel()
+}
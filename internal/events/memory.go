@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/senyabanana/tender-service/internal/db"
+)
+
+// subscriberBuffer - размер буфера канала подписчика. Если подписчик не успевает читать события
+// и буфер заполнен, новые события для него отбрасываются, чтобы не задерживать публикацию.
+const subscriberBuffer = 64
+
+// MemoryBus - шина событий в памяти процесса. События доставляются подписчикам только этого экземпляра сервиса.
+type MemoryBus struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewMemoryBus создаёт новый экземпляр MemoryBus.
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{subscribers: make(map[chan Event]struct{})}
+}
+
+// Publish доставляет событие подписчикам после фиксации транзакции из контекста.
+func (b *MemoryBus) Publish(ctx context.Context, event Event) {
+	db.AfterCommit(ctx, func() {
+		b.dispatch(event)
+	})
+}
+
+// Subscribe создаёт подписку на события.
+func (b *MemoryBus) Subscribe() *Subscription {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return &Subscription{
+		C: ch,
+		cancel: func() {
+			once.Do(func() {
+				b.mu.Lock()
+				delete(b.subscribers, ch)
+				b.mu.Unlock()
+				close(ch)
+			})
+		},
+	}
+}
+
+// dispatch немедленно рассылает событие всем подписчикам без блокировки.
+func (b *MemoryBus) dispatch(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// NotifyChannel - канал LISTEN/NOTIFY, через который реплики обмениваются событиями.
+	NotifyChannel = "tender_service_events"
+
+	// listenRetryInterval - пауза перед повторным подключением после потери соединения LISTEN.
+	listenRetryInterval = 5 * time.Second
+)
+
+// PostgresBus - шина событий поверх PostgreSQL LISTEN/NOTIFY. Событие отправляется через NOTIFY
+// в транзакции изменения и доставляется подписчикам всех реплик, включая опубликовавшую, после фиксации.
+type PostgresBus struct {
+	DB     *pgxpool.Pool
+	Logger *log.Logger
+	local  *MemoryBus
+}
+
+// NewPostgresBus создаёт новый экземпляр PostgresBus. Для получения событий необходимо запустить Listen.
+func NewPostgresBus(db *pgxpool.Pool, logger *log.Logger) *PostgresBus {
+	return &PostgresBus{DB: db, Logger: logger, local: NewMemoryBus()}
+}
+
+// Publish отправляет событие через NOTIFY в транзакции из контекста или отдельным запросом.
+// Ошибка отправки не отменяет изменение и только записывается в журнал.
+func (b *PostgresBus) Publish(ctx context.Context, event Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		b.Logger.Printf("events: failed to encode event %s: %v", event.ID, err)
+		return
+	}
+	if _, err = db.Conn(ctx, b.DB).Exec(ctx, `SELECT pg_notify($1, $2)`, NotifyChannel, string(payload)); err != nil {
+		b.Logger.Printf("events: failed to notify event %s: %v", event.ID, err)
+	}
+}
+
+// Subscribe создаёт подписку на события, полученные этой репликой через LISTEN.
+func (b *PostgresBus) Subscribe() *Subscription {
+	return b.local.Subscribe()
+}
+
+// Listen получает события через LISTEN и рассылает их локальным подписчикам до отмены контекста.
+// При потере соединения подписка на канал восстанавливается; события, отправленные в это время, теряются.
+func (b *PostgresBus) Listen(ctx context.Context) {
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		b.Logger.Printf("events: listen connection lost: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// listen держит отдельное соединение с подпиской на канал событий, пока оно не прервётся.
+func (b *PostgresBus) listen(ctx context.Context) error {
+	conn, err := b.DB.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{NotifyChannel}.Sanitize()); err != nil {
+		return err
+	}
+	defer func() {
+		// Соединение возвращается в пул, поэтому подписка на канал снимается.
+		_, _ = conn.Exec(context.Background(), "UNLISTEN *")
+	}()
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var event Event
+		if err = json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			b.Logger.Printf("events: failed to decode notification: %v", err)
+			continue
+		}
+		b.local.dispatch(event)
+	}
+}
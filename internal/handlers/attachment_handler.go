@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+const (
+	// attachmentUploadTimeout - время на обработку запроса загрузки вложения вместе с передачей файла.
+	attachmentUploadTimeout = 2 * time.Minute
+
+	// multipartOverhead - запас к максимальному размеру вложения на заголовки и границы multipart-запроса.
+	multipartOverhead = 1 << 20
+
+	// attachmentFormField - имя поля multipart-запроса с загружаемым файлом.
+	attachmentFormField = "file"
+)
+
+// readAttachmentUpload находит в multipart-запросе поле с файлом и возвращает загружаемый файл.
+// Содержимое файла читается из тела запроса по мере сохранения, а не буферизуется целиком.
+func readAttachmentUpload(w http.ResponseWriter, r *http.Request, maxSize int64) (models.AttachmentUpload, *models.ErrorResponse) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return models.AttachmentUpload{}, models.NewErrorResponse(http.StatusBadRequest, "invalid request body, must be multipart/form-data")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return models.AttachmentUpload{}, models.NewErrorResponse(http.StatusBadRequest, "missing required form field: "+attachmentFormField)
+		}
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return models.AttachmentUpload{}, models.NewErrorResponse(http.StatusRequestEntityTooLarge, "request body is too large")
+		}
+		if err != nil {
+			return models.AttachmentUpload{}, models.NewErrorResponse(http.StatusBadRequest, "invalid multipart request body")
+		}
+		if part.FormName() == attachmentFormField {
+			return models.AttachmentUpload{
+				FileName:    part.FileName(),
+				ContentType: part.Header.Get("Content-Type"),
+				Content:     part,
+			}, nil
+		}
+	}
+}
+
+// sendAttachmentContent отправляет содержимое вложения как файл для скачивания и закрывает его.
+func sendAttachmentContent(w http.ResponseWriter, logger *log.Logger, attachment *models.Attachment, content io.ReadCloser) {
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("X-Checksum-SHA256", attachment.Checksum)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		logger.Println(err)
+	}
+}
+
+// sendAttachment отправляет описание загруженного вложения вместе с ETag новой версии сущности.
+func sendAttachment(w http.ResponseWriter, logger *log.Logger, attachment *models.Attachment, version int) {
+	w.Header().Set("ETag", utils.ETag(version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(attachment); err != nil {
+		logger.Println(err)
+	}
+}
+
+// sendAttachments отправляет список вложений.
+func sendAttachments(w http.ResponseWriter, logger *log.Logger, attachments []models.Attachment) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(attachments); err != nil {
+		logger.Println(err)
+	}
+}
+
+// GetTenderAttachments обрабатывает запросы для получения списка вложений тендера.
+func (h *TenderHandler) GetTenderAttachments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	attachments, err := h.Service.GetTenderAttachments(ctx, r.PathValue("tenderId"))
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch tender attachments")
+		return
+	}
+
+	sendAttachments(w, h.Logger, attachments)
+}
+
+// GetTenderAttachment обрабатывает запросы для скачивания вложения тендера.
+func (h *TenderHandler) GetTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), attachmentUploadTimeout)
+	defer cancel()
+
+	attachment, content, err := h.Service.GetTenderAttachmentContent(ctx, r.PathValue("tenderId"), r.PathValue("attachmentId"))
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch tender attachment")
+		return
+	}
+
+	sendAttachmentContent(w, h.Logger, attachment, content)
+}
+
+// CreateTenderAttachment обрабатывает multipart-запросы для загрузки вложения тендера.
+func (h *TenderHandler) CreateTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), attachmentUploadTimeout)
+	defer cancel()
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	upload, errorResponse := readAttachmentUpload(w, r, h.Service.Attachments.MaxSize)
+	if errorResponse != nil {
+		utils.SendError(w, errorResponse)
+		return
+	}
+
+	attachment, updatedTender, err := h.Service.CreateTenderAttachment(ctx, r.PathValue("tenderId"), expectedVersion, upload)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			sendVersionedError(w, errorResponse, fromIfMatch)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to upload tender attachment")
+		return
+	}
+
+	sendAttachment(w, h.Logger, attachment, int(updatedTender.Version))
+}
+
+// DeleteTenderAttachment обрабатывает запросы для удаления вложения тендера.
+func (h *TenderHandler) DeleteTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only DELETE is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedTender, err := h.Service.DeleteTenderAttachment(ctx, r.PathValue("tenderId"), r.PathValue("attachmentId"), expectedVersion)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			sendVersionedError(w, errorResponse, fromIfMatch)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to delete tender attachment")
+		return
+	}
+
+	w.Header().Set("ETag", utils.ETag(int(updatedTender.Version)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBidAttachments обрабатывает запросы для получения списка вложений предложения.
+func (h *BidHandler) GetBidAttachments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	attachments, err := h.Service.GetBidAttachments(ctx, r.PathValue("bidId"))
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch bid attachments")
+		return
+	}
+
+	sendAttachments(w, h.Logger, attachments)
+}
+
+// GetBidAttachment обрабатывает запросы для скачивания вложения предложения.
+func (h *BidHandler) GetBidAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), attachmentUploadTimeout)
+	defer cancel()
+
+	attachment, content, err := h.Service.GetBidAttachmentContent(ctx, r.PathValue("bidId"), r.PathValue("attachmentId"))
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch bid attachment")
+		return
+	}
+
+	sendAttachmentContent(w, h.Logger, attachment, content)
+}
+
+// CreateBidAttachment обрабатывает multipart-запросы для загрузки вложения предложения.
+func (h *BidHandler) CreateBidAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), attachmentUploadTimeout)
+	defer cancel()
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	upload, errorResponse := readAttachmentUpload(w, r, h.Service.Attachments.MaxSize)
+	if errorResponse != nil {
+		utils.SendError(w, errorResponse)
+		return
+	}
+
+	attachment, updatedBid, err := h.Service.CreateBidAttachment(ctx, r.PathValue("bidId"), expectedVersion, upload)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			sendVersionedError(w, errorResponse, fromIfMatch)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to upload bid attachment")
+		return
+	}
+
+	sendAttachment(w, h.Logger, attachment, updatedBid.Version)
+}
+
+// DeleteBidAttachment обрабатывает запросы для удаления вложения предложения.
+func (h *BidHandler) DeleteBidAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only DELETE is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedBid, err := h.Service.DeleteBidAttachment(ctx, r.PathValue("bidId"), r.PathValue("attachmentId"), expectedVersion)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			sendVersionedError(w, errorResponse, fromIfMatch)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to delete bid attachment")
+		return
+	}
+
+	w.Header().Set("ETag", utils.ETag(updatedBid.Version))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// AuctionHandler - структура для обработки HTTP-запросов к аукционам по тендерам.
+type AuctionHandler struct {
+	Service *services.AuctionService
+	Logger  *log.Logger
+	Timeout time.Duration
+}
+
+// NewAuctionHandler создаёт новый экземпляр AuctionHandler.
+func NewAuctionHandler(service *services.AuctionService, logger *log.Logger, timeout time.Duration) *AuctionHandler {
+	return &AuctionHandler{
+		Service: service,
+		Logger:  logger,
+		Timeout: timeout,
+	}
+}
+
+// GetAuction обрабатывает запросы для получения аукциона по тендеру.
+func (h *AuctionHandler) GetAuction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	auction, err := h.Service.GetAuction(ctx, tenderId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch auction")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(auction); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// SaveAuction обрабатывает запросы для настройки аукциона по тендеру.
+func (h *AuctionHandler) SaveAuction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only PUT is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	var auctionReq models.AuctionRequest
+	if err := json.NewDecoder(r.Body).Decode(&auctionReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	auction, err := h.Service.SaveAuction(ctx, tenderId, auctionReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to save auction")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(auction); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetLeaderboard обрабатывает запросы для получения таблицы лидеров аукциона.
+func (h *AuctionHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	leaderboard, err := h.Service.GetLeaderboard(ctx, tenderId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch auction leaderboard")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(leaderboard); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// Rebid обрабатывает запросы для снижения цены предложения в раунде аукциона.
+func (h *AuctionHandler) Rebid(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	bidId := r.PathValue("bidId")
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var rebidReq models.RebidRequest
+	if err := json.NewDecoder(r.Body).Decode(&rebidReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if rebidReq.ExpectedVersion < 0 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid expectedVersion field, must be a positive integer")
+		return
+	}
+	if fromIfMatch || rebidReq.ExpectedVersion == 0 {
+		rebidReq.ExpectedVersion = expectedVersion
+	}
+
+	bid, err := h.Service.Rebid(ctx, bidId, rebidReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			sendVersionedError(w, errorResponse, fromIfMatch)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to rebid")
+		return
+	}
+
+	w.Header().Set("ETag", utils.ETag(bid.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(bid); err != nil {
+		h.Logger.Println(err)
+	}
+}
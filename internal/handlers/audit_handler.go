@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// AuditHandler - структура для обработки HTTP-запросов к журналу аудита.
+type AuditHandler struct {
+	Service *services.AuditService
+	Logger  *log.Logger
+	Timeout time.Duration
+}
+
+// NewAuditHandler создаёт новый экземпляр AuditHandler.
+func NewAuditHandler(service *services.AuditService, logger *log.Logger, timeout time.Duration) *AuditHandler {
+	return &AuditHandler{
+		Service: service,
+		Logger:  logger,
+		Timeout: timeout,
+	}
+}
+
+// GetEvents обрабатывает запросы для получения записей журнала аудита.
+func (h *AuditHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	query := r.URL.Query()
+	from, err := utils.ParseTimeParam(query, "from")
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := utils.ParseTimeParam(query, "to")
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, err := h.Service.GetEvents(ctx, query.Get("entityId"), query.Get("actor"), from, to, query.Get("limit"), query.Get("offset"))
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch audit events")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(events); err != nil {
+		h.Logger.Println(err)
+	}
+}
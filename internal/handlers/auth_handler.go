@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// AuthHandler - структура для обработки HTTP-запросов аутентификации.
+type AuthHandler struct {
+	Service *services.AuthService
+	Logger  *log.Logger
+	Timeout time.Duration
+}
+
+// NewAuthHandler создаёт новый экземпляр AuthHandler.
+func NewAuthHandler(service *services.AuthService, logger *log.Logger, timeout time.Duration) *AuthHandler {
+	return &AuthHandler{
+		Service: service,
+		Logger:  logger,
+		Timeout: timeout,
+	}
+}
+
+// IssueToken обрабатывает запросы на выпуск токена доступа.
+func (h *AuthHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	var tokenReq models.TokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&tokenReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	token, err := h.Service.IssueToken(ctx, tokenReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to issue token")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(token); err != nil {
+		h.Logger.Println(err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/pagination"
 	"github.com/senyabanana/tender-service/internal/services"
 	"github.com/senyabanana/tender-service/internal/utils"
 
@@ -21,15 +22,17 @@ type BidHandler struct {
 	Service *services.BidService
 	Logger  *log.Logger
 	Timeout time.Duration
+	Cursors *pagination.Signer
 	dbPool  *pgxpool.Pool
 }
 
 // NewBIdHandler создает новый экземпляр BidHandler.
-func NewBIdHandler(service *services.BidService, logger *log.Logger, timeout time.Duration, dbPool *pgxpool.Pool) *BidHandler {
+func NewBIdHandler(service *services.BidService, logger *log.Logger, timeout time.Duration, dbPool *pgxpool.Pool, cursors *pagination.Signer) *BidHandler {
 	return &BidHandler{
 		Service: service,
 		Logger:  logger,
 		Timeout: timeout,
+		Cursors: cursors,
 		dbPool:  dbPool,
 	}
 }
@@ -63,6 +66,7 @@ func (h *BidHandler) CreateBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", utils.ETag(newBid.Version))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err = json.NewEncoder(w).Encode(newBid); err != nil {
@@ -80,11 +84,13 @@ func (h *BidHandler) GetUserBid(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
 	defer cancel()
 
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
-	username := r.URL.Query().Get("username")
+	page, err := utils.ParsePage(r.URL.Query(), h.Cursors, userBidsList, "")
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	userBids, err := h.Service.GetUserBid(ctx, limitStr, offsetStr, username)
+	userBids, err := h.Service.GetUserBid(ctx, page)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -96,17 +102,13 @@ func (h *BidHandler) GetUserBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(userBids) == 0 {
+	if len(userBids.Items) == 0 && !page.Cursor {
 		h.Logger.Println(err)
 		utils.SendErrorResponse(w, http.StatusNotFound, "no bids found for this user")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err = json.NewEncoder(w).Encode(userBids); err != nil {
-		h.Logger.Println(err)
-	}
+	sendPage(w, h.Logger, h.Cursors, page, userBidsList, "", userBids)
 }
 
 // GetTenderBid обрабатывает запросы для получения списка предложений по тендеру.
@@ -120,11 +122,20 @@ func (h *BidHandler) GetTenderBid(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	tenderId := r.PathValue("tenderId")
-	username := r.URL.Query().Get("username")
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
 
-	bids, err := h.Service.GetTenderBid(ctx, username, tenderId, limitStr, offsetStr)
+	filter, err := utils.ParseBidFilter(r.URL.Query())
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := utils.ParsePage(r.URL.Query(), h.Cursors, tenderBidsList, filter.Sort)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bids, sealedBids, err := h.Service.GetTenderBid(ctx, tenderId, page, filter)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -135,16 +146,21 @@ func (h *BidHandler) GetTenderBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(bids) == 0 {
-		utils.SendErrorResponse(w, http.StatusNotFound, "no bids found for the specified tender")
+	if sealedBids != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err = json.NewEncoder(w).Encode(sealedBids); err != nil {
+			h.Logger.Println(err)
+		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err = json.NewEncoder(w).Encode(bids); err != nil {
-		h.Logger.Println(err)
+	if len(bids.Items) == 0 && !page.Cursor {
+		utils.SendErrorResponse(w, http.StatusNotFound, "no bids found for the specified tender")
+		return
 	}
+
+	sendPage(w, h.Logger, h.Cursors, page, tenderBidsList, filter.Sort, bids)
 }
 
 // GetBidStatus обрабатывает запросы для получения статуса предложения.
@@ -158,9 +174,8 @@ func (h *BidHandler) GetBidStatus(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	bidId := r.PathValue("bidId")
-	username := r.URL.Query().Get("username")
 
-	status, err := h.Service.GetBidStatus(ctx, bidId, username)
+	status, err := h.Service.GetBidStatus(ctx, bidId)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -189,14 +204,19 @@ func (h *BidHandler) UpdateBidStatus(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	bidId := r.PathValue("bidId")
-	username := r.URL.Query().Get("username")
 	status := r.URL.Query().Get("status")
 
-	bid, err := h.Service.UpdateBidStatus(ctx, bidId, status, username)
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bid, err := h.Service.UpdateBidStatus(ctx, bidId, status, expectedVersion)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
-			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			sendVersionedError(w, errorResponse, fromIfMatch)
 			return
 		}
 		h.Logger.Println(err)
@@ -204,6 +224,7 @@ func (h *BidHandler) UpdateBidStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", utils.ETag(bid.Version))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err = json.NewEncoder(w).Encode(bid); err != nil {
@@ -222,7 +243,12 @@ func (h *BidHandler) EditBid(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	bidId := r.PathValue("bidId")
-	username := r.URL.Query().Get("username")
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	var updateFields map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&updateFields); err != nil {
@@ -230,11 +256,20 @@ func (h *BidHandler) EditBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedBid, err := h.Service.EditBid(ctx, bidId, username, updateFields)
+	bodyVersion, ok := expectedVersionFromBody(updateFields)
+	if !ok {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid expectedVersion field, must be a positive integer")
+		return
+	}
+	if !fromIfMatch && bodyVersion != 0 {
+		expectedVersion = bodyVersion
+	}
+
+	updatedBid, err := h.Service.EditBid(ctx, bidId, expectedVersion, updateFields)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
-			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			sendVersionedError(w, errorResponse, fromIfMatch)
 			return
 		}
 		h.Logger.Println(err)
@@ -242,6 +277,7 @@ func (h *BidHandler) EditBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", utils.ETag(updatedBid.Version))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err = json.NewEncoder(w).Encode(updatedBid); err != nil {
@@ -261,9 +297,8 @@ func (h *BidHandler) SubmitBidDecision(w http.ResponseWriter, r *http.Request) {
 
 	bidId := r.PathValue("bidId")
 	decision := r.URL.Query().Get("decision")
-	username := r.URL.Query().Get("username")
 
-	bid, err := h.Service.SubmitBidDecision(ctx, bidId, username, decision)
+	bid, err := h.Service.SubmitBidDecision(ctx, bidId, decision)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -294,7 +329,6 @@ func (h *BidHandler) SubmitBidFeedback(w http.ResponseWriter, r *http.Request) {
 
 	bidId := r.PathValue("bidId")
 	bidFeedback := r.URL.Query().Get("bidFeedback")
-	username := r.URL.Query().Get("username")
 
 	review := models.BidReview{
 		ID:          uuid.New().String(),
@@ -303,7 +337,7 @@ func (h *BidHandler) SubmitBidFeedback(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:   time.Now().UTC(),
 	}
 
-	bid, err := h.Service.SubmitBidFeedback(ctx, review, bidId, bidFeedback, username)
+	bid, err := h.Service.SubmitBidFeedback(ctx, review, bidId, bidFeedback)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -334,13 +368,18 @@ func (h *BidHandler) RollbackBid(w http.ResponseWriter, r *http.Request) {
 
 	bidId := r.PathValue("bidId")
 	versionStr := r.PathValue("version")
-	username := r.URL.Query().Get("username")
 
-	bid, err := h.Service.RollbackBid(ctx, bidId, username, versionStr)
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bid, err := h.Service.RollbackBid(ctx, bidId, versionStr, expectedVersion)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
-			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			sendVersionedError(w, errorResponse, fromIfMatch)
 			return
 		}
 		h.Logger.Println(err)
@@ -348,6 +387,7 @@ func (h *BidHandler) RollbackBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", utils.ETag(bid.Version))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err = json.NewEncoder(w).Encode(bid); err != nil {
@@ -367,11 +407,14 @@ func (h *BidHandler) GetBidReviews(w http.ResponseWriter, r *http.Request) {
 
 	tenderId := r.PathValue("tenderId")
 	authorUsername := r.URL.Query().Get("authorUsername")
-	requesterUsername := r.URL.Query().Get("requesterUsername")
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
 
-	reviews, err := h.Service.GetBidReviews(ctx, tenderId, authorUsername, requesterUsername, limitStr, offsetStr)
+	page, err := utils.ParsePage(r.URL.Query(), h.Cursors, bidReviewsList, "")
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reviews, err := h.Service.GetBidReviews(ctx, tenderId, authorUsername, page)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -383,14 +426,106 @@ func (h *BidHandler) GetBidReviews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(reviews) == 0 {
+	if len(reviews.Items) == 0 && !page.Cursor {
 		utils.SendErrorResponse(w, http.StatusNotFound, fmt.Sprintf("%s has no bids or reviews on tender", authorUsername))
 		return
 	}
 
+	sendPage(w, h.Logger, h.Cursors, page, bidReviewsList, "", reviews)
+}
+
+// GetBidVersions обрабатывает запросы для получения списка версий предложения.
+func (h *BidHandler) GetBidVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	bidId := r.PathValue("bidId")
+
+	versions, err := h.Service.GetBidVersions(ctx, bidId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch bid versions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(versions); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetBidVersion обрабатывает запросы для получения снимка предложения указанной версии.
+func (h *BidHandler) GetBidVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	bidId := r.PathValue("bidId")
+	versionStr := r.PathValue("version")
+
+	bid, err := h.Service.GetBidVersion(ctx, bidId, versionStr)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch bid version")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(bid); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// DiffBidVersions обрабатывает запросы для сравнения двух версий предложения.
+func (h *BidHandler) DiffBidVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	bidId := r.PathValue("bidId")
+	fromStr := r.URL.Query().Get("from")
+	toStr := r.URL.Query().Get("to")
+
+	diff, err := h.Service.DiffBidVersions(ctx, bidId, fromStr, toStr)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to compare bid versions")
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err = json.NewEncoder(w).Encode(reviews); err != nil {
+	if err = json.NewEncoder(w).Encode(diff); err != nil {
 		h.Logger.Println(err)
 	}
 }
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// GetBidMessages обрабатывает запросы для получения переписки по предложению.
+func (h *BidHandler) GetBidMessages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	page, err := utils.ParsePage(r.URL.Query(), h.Cursors, bidMessagesList, "")
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	messages, err := h.Service.GetBidMessages(ctx, r.PathValue("bidId"), page)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch bid messages")
+		return
+	}
+
+	if messages.Items == nil {
+		messages.Items = []models.BidMessage{}
+	}
+	sendPage(w, h.Logger, h.Cursors, page, bidMessagesList, "", messages)
+}
+
+// SendBidMessage обрабатывает запросы для отправки сообщения в переписку по предложению.
+func (h *BidHandler) SendBidMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	var messageReq models.BidMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&messageReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	message, err := h.Service.SendBidMessage(ctx, r.PathValue("bidId"), messageReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to send bid message")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(message); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetUnreadBidMessages обрабатывает запросы для получения числа непрочитанных сообщений по предложениям.
+func (h *BidHandler) GetUnreadBidMessages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	unread, err := h.Service.GetUnreadBidMessages(ctx)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch unread bid messages")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(unread); err != nil {
+		h.Logger.Println(err)
+	}
+}
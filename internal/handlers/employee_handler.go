@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// EmployeeHandler - структура для обработки HTTP-запросов к сотрудникам.
+type EmployeeHandler struct {
+	Service *services.EmployeeService
+	Logger  *log.Logger
+	Timeout time.Duration
+}
+
+// NewEmployeeHandler создаёт новый экземпляр EmployeeHandler.
+func NewEmployeeHandler(service *services.EmployeeService, logger *log.Logger, timeout time.Duration) *EmployeeHandler {
+	return &EmployeeHandler{
+		Service: service,
+		Logger:  logger,
+		Timeout: timeout,
+	}
+}
+
+// CreateEmployee обрабатывает запросы для регистрации сотрудника.
+func (h *EmployeeHandler) CreateEmployee(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	var employeeReq models.EmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&employeeReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	employee, err := h.Service.CreateEmployee(ctx, employeeReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to create employee")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(employee); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetEmployees обрабатывает запросы для получения списка сотрудников.
+func (h *EmployeeHandler) GetEmployees(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+
+	employees, err := h.Service.GetEmployees(ctx, limitStr, offsetStr)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch employees")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(employees); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetEmployee обрабатывает запросы для получения сотрудника.
+func (h *EmployeeHandler) GetEmployee(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	employeeId := r.PathValue("employeeId")
+
+	employee, err := h.Service.GetEmployee(ctx, employeeId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch employee")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(employee); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// UpdateEmployee обрабатывает запросы для изменения данных сотрудника.
+func (h *EmployeeHandler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only PATCH is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	employeeId := r.PathValue("employeeId")
+
+	var updateFields map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updateFields); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	employee, err := h.Service.UpdateEmployee(ctx, employeeId, updateFields)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to update employee")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(employee); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// DeleteEmployee обрабатывает запросы для удаления сотрудника.
+func (h *EmployeeHandler) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only DELETE is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	employeeId := r.PathValue("employeeId")
+
+	if err := h.Service.DeleteEmployee(ctx, employeeId); err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to delete employee")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
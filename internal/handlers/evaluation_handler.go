@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// EvaluationHandler - структура для обработки HTTP-запросов к критериям и оценке предложений.
+type EvaluationHandler struct {
+	Service *services.EvaluationService
+	Logger  *log.Logger
+	Timeout time.Duration
+}
+
+// NewEvaluationHandler создаёт новый экземпляр EvaluationHandler.
+func NewEvaluationHandler(service *services.EvaluationService, logger *log.Logger, timeout time.Duration) *EvaluationHandler {
+	return &EvaluationHandler{
+		Service: service,
+		Logger:  logger,
+		Timeout: timeout,
+	}
+}
+
+// GetCriteria обрабатывает запросы для получения критериев оценки тендера.
+func (h *EvaluationHandler) GetCriteria(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	criteria, err := h.Service.GetCriteria(ctx, tenderId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch evaluation criteria")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(criteria); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// CreateCriterion обрабатывает запросы для добавления критерия оценки в тендер.
+func (h *EvaluationHandler) CreateCriterion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	var criterionReq models.EvaluationCriterionRequest
+	if err := json.NewDecoder(r.Body).Decode(&criterionReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	criterion, err := h.Service.CreateCriterion(ctx, tenderId, criterionReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to create evaluation criterion")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(criterion); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// DeleteCriterion обрабатывает запросы удаления критерия оценки тендера.
+func (h *EvaluationHandler) DeleteCriterion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only DELETE is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+	criterionId := r.PathValue("criterionId")
+
+	if err := h.Service.DeleteCriterion(ctx, tenderId, criterionId); err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to delete evaluation criterion")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ScoreBid обрабатывает запросы для оценки предложения по критериям тендера.
+func (h *EvaluationHandler) ScoreBid(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only PUT is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	bidId := r.PathValue("bidId")
+
+	var scores []models.BidScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&scores); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	saved, err := h.Service.ScoreBid(ctx, bidId, scores)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to score bid")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(saved); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetEvaluation обрабатывает запросы для получения сравнительной матрицы предложений по тендеру.
+func (h *EvaluationHandler) GetEvaluation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	evaluation, err := h.Service.GetEvaluation(ctx, tenderId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to evaluate bids")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(evaluation); err != nil {
+		h.Logger.Println(err)
+	}
+}
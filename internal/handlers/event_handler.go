@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// EventHandler - структура для обработки подписок на поток событий (Server-Sent Events).
+type EventHandler struct {
+	Service   *services.EventService
+	Logger    *log.Logger
+	Heartbeat time.Duration
+}
+
+// NewEventHandler создаёт новый экземпляр EventHandler. Пока событий нет, клиенту раз в heartbeat
+// отправляется комментарий, чтобы промежуточные прокси не закрывали соединение.
+func NewEventHandler(service *services.EventService, logger *log.Logger, heartbeat time.Duration) *EventHandler {
+	return &EventHandler{
+		Service:   service,
+		Logger:    logger,
+		Heartbeat: heartbeat,
+	}
+}
+
+// StreamEvents обрабатывает запросы подписки на события об изменениях тендеров и предложений.
+// Соединение остаётся открытым, пока клиент не отключится.
+func (h *EventHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	ctx := r.Context()
+	tenderId := r.URL.Query().Get("tenderId")
+
+	stream, err := h.Service.Subscribe(ctx, tenderId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to subscribe to events")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				h.Logger.Println(err)
+				continue
+			}
+			if _, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// OrganizationHandler - структура для обработки HTTP-запросов к организациям.
+type OrganizationHandler struct {
+	Service *services.OrganizationService
+	Logger  *log.Logger
+	Timeout time.Duration
+}
+
+// NewOrganizationHandler создаёт новый экземпляр OrganizationHandler.
+func NewOrganizationHandler(service *services.OrganizationService, logger *log.Logger, timeout time.Duration) *OrganizationHandler {
+	return &OrganizationHandler{
+		Service: service,
+		Logger:  logger,
+		Timeout: timeout,
+	}
+}
+
+// CreateOrganization обрабатывает запросы для создания организации.
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	var organizationReq models.OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&organizationReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	organization, err := h.Service.CreateOrganization(ctx, organizationReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to create organization")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(organization); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetOrganizations обрабатывает запросы для получения списка организаций.
+func (h *OrganizationHandler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+
+	organizations, err := h.Service.GetOrganizations(ctx, limitStr, offsetStr)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch organizations")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(organizations); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetOrganization обрабатывает запросы для получения организации.
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+
+	organization, err := h.Service.GetOrganization(ctx, organizationId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch organization")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(organization); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// UpdateOrganization обрабатывает запросы для изменения организации.
+func (h *OrganizationHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only PATCH is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+
+	var updateFields map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updateFields); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	organization, err := h.Service.UpdateOrganization(ctx, organizationId, updateFields)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to update organization")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(organization); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// DeleteOrganization обрабатывает запросы для удаления организации.
+func (h *OrganizationHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only DELETE is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+
+	if err := h.Service.DeleteOrganization(ctx, organizationId); err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to delete organization")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetResponsibles обрабатывает запросы для получения списка ответственных за организацию.
+func (h *OrganizationHandler) GetResponsibles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+
+	responsibles, err := h.Service.GetResponsibles(ctx, organizationId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch responsibles")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(responsibles); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// AddResponsible обрабатывает запросы для назначения ответственного за организацию.
+func (h *OrganizationHandler) AddResponsible(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only PUT is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+	userId := r.PathValue("userId")
+
+	responsibles, err := h.Service.AddResponsible(ctx, organizationId, userId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to add responsible")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(responsibles); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// RemoveResponsible обрабатывает запросы для снятия ответственного за организацию.
+func (h *OrganizationHandler) RemoveResponsible(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only DELETE is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+	userId := r.PathValue("userId")
+
+	responsibles, err := h.Service.RemoveResponsible(ctx, organizationId, userId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to remove responsible")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(responsibles); err != nil {
+		h.Logger.Println(err)
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/pagination"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// Названия списков, к которым привязаны курсоры: курсор одного списка нельзя использовать в другом.
+const (
+	tendersList     = "tenders"
+	userTendersList = "tenders.my"
+	userBidsList    = "bids.my"
+	tenderBidsList  = "bids.tender"
+	bidReviewsList  = "bids.reviews"
+	questionsList   = "tenders.questions"
+	bidMessagesList = "bids.messages"
+)
+
+// sendPage отправляет страницу списка list с сортировкой sort. Если страница запрошена по курсору,
+// отправляется конверт с элементами и курсором следующей страницы, иначе - массив элементов.
+func sendPage[T any](w http.ResponseWriter, logger *log.Logger, cursors *pagination.Signer, page models.Page, list, sort string, result models.Paged[T]) {
+	var body any = result.Items
+	if page.Cursor {
+		cursorPage := models.CursorPage[T]{Items: result.Items}
+		if result.Next != nil {
+			nextCursor, err := cursors.Encode(pagination.Cursor{List: list, Sort: sort, After: result.Next})
+			if err != nil {
+				logger.Println(err)
+				utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to encode cursor")
+				return
+			}
+			cursorPage.NextCursor = nextCursor
+		}
+		body = cursorPage
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Println(err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/pagination"
 	"github.com/senyabanana/tender-service/internal/services"
 	"github.com/senyabanana/tender-service/internal/utils"
 
@@ -19,15 +20,17 @@ type TenderHandler struct {
 	Service *services.TenderService
 	Logger  *log.Logger
 	Timeout time.Duration
+	Cursors *pagination.Signer
 	dbPool  *pgxpool.Pool
 }
 
 // NewTenderHandler создаёт новый экземпляр TenderHandler.
-func NewTenderHandler(service *services.TenderService, logger *log.Logger, timeout time.Duration, dbPool *pgxpool.Pool) *TenderHandler {
+func NewTenderHandler(service *services.TenderService, logger *log.Logger, timeout time.Duration, dbPool *pgxpool.Pool, cursors *pagination.Signer) *TenderHandler {
 	return &TenderHandler{
 		Service: service,
 		Logger:  logger,
 		Timeout: timeout,
+		Cursors: cursors,
 		dbPool:  dbPool,
 	}
 }
@@ -42,17 +45,19 @@ func (h *TenderHandler) GetTenders(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
 	defer cancel()
 
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
-	serviceTypes := r.URL.Query()["service_type"]
+	filter, err := utils.ParseTenderFilter(r.URL.Query())
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+	page, err := utils.ParsePage(r.URL.Query(), h.Cursors, tendersList, filter.Sort)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	tenders, err := h.Service.FetchTenders(ctx, limit, offset, serviceTypes)
+	tenders, err := h.Service.FetchTenders(ctx, page, filter)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -64,9 +69,47 @@ func (h *TenderHandler) GetTenders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sendPage(w, h.Logger, h.Cursors, page, tendersList, filter.Sort, tenders)
+}
+
+// SearchTenders обрабатывает запросы для полнотекстового поиска тендеров с фильтрами и фасетами.
+func (h *TenderHandler) SearchTenders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	query := r.URL.Query()
+	limit, offset, err := utils.ParseLimitOffset(query.Get("limit"), query.Get("offset"))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	search, err := utils.ParseTenderSearch(query)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.Service.SearchTenders(ctx, search, limit, offset)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to search tenders")
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err = json.NewEncoder(w).Encode(tenders); err != nil {
+	if err = json.NewEncoder(w).Encode(result); err != nil {
 		h.Logger.Println(err)
 	}
 }
@@ -100,6 +143,7 @@ func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", utils.ETag(int(tender.Version)))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err = json.NewEncoder(w).Encode(tender); err != nil {
@@ -117,11 +161,19 @@ func (h *TenderHandler) GetUserTender(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
 	defer cancel()
 
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
-	username := r.URL.Query().Get("username")
+	filter, err := utils.ParseTenderFilter(r.URL.Query())
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	tenders, err := h.Service.GetUserTender(ctx, limitStr, offsetStr, username)
+	page, err := utils.ParsePage(r.URL.Query(), h.Cursors, userTendersList, filter.Sort)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenders, err := h.Service.GetUserTender(ctx, page, filter)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -133,17 +185,13 @@ func (h *TenderHandler) GetUserTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(tenders) == 0 {
+	if len(tenders.Items) == 0 && !page.Cursor {
 		h.Logger.Println(err)
 		utils.SendErrorResponse(w, http.StatusNotFound, "no tenders found for this user")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err = json.NewEncoder(w).Encode(tenders); err != nil {
-		h.Logger.Println(err)
-	}
+	sendPage(w, h.Logger, h.Cursors, page, userTendersList, filter.Sort, tenders)
 }
 
 // GetTenderStatus обрабатывает запросы для получения статуса тендера.
@@ -157,9 +205,8 @@ func (h *TenderHandler) GetTenderStatus(w http.ResponseWriter, r *http.Request)
 	defer cancel()
 
 	tenderId := r.PathValue("tenderId")
-	username := r.URL.Query().Get("username")
 
-	status, err := h.Service.GetTenderStatus(ctx, tenderId, username)
+	status, err := h.Service.GetTenderStatus(ctx, tenderId)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
@@ -190,13 +237,18 @@ func (h *TenderHandler) UpdateTenderStatus(w http.ResponseWriter, r *http.Reques
 
 	tenderId := r.PathValue("tenderId")
 	status := r.URL.Query().Get("status")
-	username := r.URL.Query().Get("username")
 
-	tender, err := h.Service.UpdateTenderStatus(ctx, tenderId, status, username)
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tender, err := h.Service.UpdateTenderStatus(ctx, tenderId, status, expectedVersion)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
-			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			sendVersionedError(w, errorResponse, fromIfMatch)
 			return
 		}
 		h.Logger.Println(err)
@@ -204,6 +256,7 @@ func (h *TenderHandler) UpdateTenderStatus(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	w.Header().Set("ETag", utils.ETag(int(tender.Version)))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err = json.NewEncoder(w).Encode(tender); err != nil {
@@ -222,7 +275,12 @@ func (h *TenderHandler) EditTender(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	tenderId := r.PathValue("tenderId")
-	username := r.URL.Query().Get("username")
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	var updateFields map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&updateFields); err != nil {
@@ -230,11 +288,20 @@ func (h *TenderHandler) EditTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedTender, err := h.Service.EditTender(ctx, tenderId, username, updateFields)
+	bodyVersion, ok := expectedVersionFromBody(updateFields)
+	if !ok {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid expectedVersion field, must be a positive integer")
+		return
+	}
+	if !fromIfMatch && bodyVersion != 0 {
+		expectedVersion = bodyVersion
+	}
+
+	updatedTender, err := h.Service.EditTender(ctx, tenderId, expectedVersion, updateFields)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
-			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			sendVersionedError(w, errorResponse, fromIfMatch)
 			return
 		}
 		h.Logger.Println(err)
@@ -242,6 +309,7 @@ func (h *TenderHandler) EditTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", utils.ETag(int(updatedTender.Version)))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err = json.NewEncoder(w).Encode(updatedTender); err != nil {
@@ -261,13 +329,18 @@ func (h *TenderHandler) RollbackTender(w http.ResponseWriter, r *http.Request) {
 
 	tenderId := r.PathValue("tenderId")
 	versionStr := r.PathValue("version")
-	username := r.URL.Query().Get("username")
 
-	updatedTender, err := h.Service.RollbackTender(ctx, tenderId, username, versionStr)
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedTender, err := h.Service.RollbackTender(ctx, tenderId, versionStr, expectedVersion)
 	if err != nil {
 		if errorResponse, ok := err.(*models.ErrorResponse); ok {
 			h.Logger.Println(err)
-			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			sendVersionedError(w, errorResponse, fromIfMatch)
 			return
 		}
 		h.Logger.Println(err)
@@ -275,9 +348,106 @@ func (h *TenderHandler) RollbackTender(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", utils.ETag(int(updatedTender.Version)))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err = json.NewEncoder(w).Encode(updatedTender); err != nil {
 		h.Logger.Println(err)
 	}
 }
+
+// GetTenderVersions обрабатывает запросы для получения списка версий тендера.
+func (h *TenderHandler) GetTenderVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	versions, err := h.Service.GetTenderVersions(ctx, tenderId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch tender versions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(versions); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetTenderVersion обрабатывает запросы для получения снимка тендера указанной версии.
+func (h *TenderHandler) GetTenderVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+	versionStr := r.PathValue("version")
+
+	tender, err := h.Service.GetTenderVersion(ctx, tenderId, versionStr)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch tender version")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(tender); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// DiffTenderVersions обрабатывает запросы для сравнения двух версий тендера.
+func (h *TenderHandler) DiffTenderVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+	fromStr := r.URL.Query().Get("from")
+	toStr := r.URL.Query().Get("to")
+
+	diff, err := h.Service.DiffTenderVersions(ctx, tenderId, fromStr, toStr)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to compare tender versions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(diff); err != nil {
+		h.Logger.Println(err)
+	}
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// GetTenderLots обрабатывает запросы для получения списка лотов тендера.
+func (h *TenderHandler) GetTenderLots(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	lots, err := h.Service.GetTenderLots(ctx, tenderId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch tender lots")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(lots); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// GetTenderLot обрабатывает запросы для получения лота тендера.
+func (h *TenderHandler) GetTenderLot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+	lotId := r.PathValue("lotId")
+
+	lot, err := h.Service.GetTenderLot(ctx, tenderId, lotId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch tender lot")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(lot); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// CreateTenderLot обрабатывает запросы для добавления лота в тендер.
+func (h *TenderHandler) CreateTenderLot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var lotReq models.TenderLotRequest
+	if err := json.NewDecoder(r.Body).Decode(&lotReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	lot, updatedTender, err := h.Service.CreateTenderLot(ctx, tenderId, expectedVersion, lotReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			sendVersionedError(w, errorResponse, fromIfMatch)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to create tender lot")
+		return
+	}
+
+	w.Header().Set("ETag", utils.ETag(int(updatedTender.Version)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(lot); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// EditTenderLot обрабатывает запросы изменения лота тендера.
+func (h *TenderHandler) EditTenderLot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only PATCH is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+	lotId := r.PathValue("lotId")
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var updateFields map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updateFields); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	bodyVersion, ok := expectedVersionFromBody(updateFields)
+	if !ok {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid expectedVersion field, must be a positive integer")
+		return
+	}
+	if !fromIfMatch && bodyVersion != 0 {
+		expectedVersion = bodyVersion
+	}
+
+	lot, updatedTender, err := h.Service.EditTenderLot(ctx, tenderId, lotId, expectedVersion, updateFields)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			sendVersionedError(w, errorResponse, fromIfMatch)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to update tender lot")
+		return
+	}
+
+	w.Header().Set("ETag", utils.ETag(int(updatedTender.Version)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(lot); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// DeleteTenderLot обрабатывает запросы удаления лота тендера.
+func (h *TenderHandler) DeleteTenderLot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only DELETE is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	tenderId := r.PathValue("tenderId")
+	lotId := r.PathValue("lotId")
+
+	expectedVersion, fromIfMatch, err := utils.ParseExpectedVersion(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedTender, err := h.Service.DeleteTenderLot(ctx, tenderId, lotId, expectedVersion)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			sendVersionedError(w, errorResponse, fromIfMatch)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to delete tender lot")
+		return
+	}
+
+	w.Header().Set("ETag", utils.ETag(int(updatedTender.Version)))
+	w.WriteHeader(http.StatusNoContent)
+}
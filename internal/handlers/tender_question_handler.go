@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/pagination"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// TenderQuestionHandler - структура для обработки HTTP-запросов к вопросам и ответам по тендерам.
+type TenderQuestionHandler struct {
+	Service *services.TenderQuestionService
+	Logger  *log.Logger
+	Timeout time.Duration
+	Cursors *pagination.Signer
+}
+
+// NewTenderQuestionHandler создаёт новый экземпляр TenderQuestionHandler.
+func NewTenderQuestionHandler(service *services.TenderQuestionService, logger *log.Logger, timeout time.Duration, cursors *pagination.Signer) *TenderQuestionHandler {
+	return &TenderQuestionHandler{
+		Service: service,
+		Logger:  logger,
+		Timeout: timeout,
+		Cursors: cursors,
+	}
+}
+
+// GetQuestions обрабатывает запросы для получения списка вопросов и ответов по тендеру.
+func (h *TenderQuestionHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	page, err := utils.ParsePage(r.URL.Query(), h.Cursors, questionsList, "")
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	questions, err := h.Service.GetQuestions(ctx, r.PathValue("tenderId"), page)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch tender questions")
+		return
+	}
+
+	if questions.Items == nil {
+		questions.Items = []models.TenderQuestion{}
+	}
+	sendPage(w, h.Logger, h.Cursors, page, questionsList, "", questions)
+}
+
+// AskQuestion обрабатывает запросы для вопроса по тендеру.
+func (h *TenderQuestionHandler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	var questionReq models.TenderQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&questionReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	question, err := h.Service.AskQuestion(ctx, r.PathValue("tenderId"), questionReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to ask tender question")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(question); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// AnswerQuestion обрабатывает запросы для ответа на вопрос по тендеру.
+func (h *TenderQuestionHandler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only PUT is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	var answerReq models.TenderAnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&answerReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	question, err := h.Service.AnswerQuestion(ctx, r.PathValue("tenderId"), r.PathValue("questionId"), answerReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to answer tender question")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(question); err != nil {
+		h.Logger.Println(err)
+	}
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// expectedVersionFromBody извлекает поле expectedVersion из тела запроса на редактирование
+// и удаляет его из списка изменяемых полей. Возвращает false, если значение поля некорректно.
+func expectedVersionFromBody(updateFields map[string]interface{}) (int, bool) {
+	value, ok := updateFields["expectedVersion"]
+	if !ok {
+		return 0, true
+	}
+	delete(updateFields, "expectedVersion")
+
+	version, ok := value.(float64)
+	if !ok || version <= 0 || version != float64(int(version)) {
+		return 0, false
+	}
+	return int(version), true
+}
+
+// sendVersionedError отправляет ошибку сервиса. Конфликт версий, обнаруженный
+// по заголовку If-Match, возвращается со статусом 412 Precondition Failed.
+func sendVersionedError(w http.ResponseWriter, errorResponse *models.ErrorResponse, fromIfMatch bool) {
+	if errorResponse.CurrentVersion != nil && fromIfMatch {
+		errorResponse.StatusCode = http.StatusPreconditionFailed
+	}
+	utils.SendError(w, errorResponse)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/services"
+	"github.com/senyabanana/tender-service/internal/utils"
+)
+
+// WebhookHandler - структура для обработки HTTP-запросов к вебхукам организаций.
+type WebhookHandler struct {
+	Service *services.WebhookService
+	Logger  *log.Logger
+	Timeout time.Duration
+}
+
+// NewWebhookHandler создаёт новый экземпляр WebhookHandler.
+func NewWebhookHandler(service *services.WebhookService, logger *log.Logger, timeout time.Duration) *WebhookHandler {
+	return &WebhookHandler{
+		Service: service,
+		Logger:  logger,
+		Timeout: timeout,
+	}
+}
+
+// GetWebhooks обрабатывает запросы для получения вебхуков организации.
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+
+	webhooks, err := h.Service.GetWebhooks(ctx, organizationId)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch webhooks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(webhooks); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// CreateWebhook обрабатывает запросы для регистрации вебхука организации.
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only POST is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+
+	var webhookReq models.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&webhookReq); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	webhook, err := h.Service.CreateWebhook(ctx, organizationId, webhookReq)
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to create webhook")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err = json.NewEncoder(w).Encode(webhook); err != nil {
+		h.Logger.Println(err)
+	}
+}
+
+// DeleteWebhook обрабатывает запросы для удаления вебхука организации.
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only DELETE is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+	webhookId := r.PathValue("webhookId")
+
+	if err := h.Service.DeleteWebhook(ctx, organizationId, webhookId); err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeadLetters обрабатывает запросы для получения недоставленных событий вебхука.
+func (h *WebhookHandler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "invalid method, only GET is allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.Timeout)
+	defer cancel()
+
+	organizationId := r.PathValue("organizationId")
+	webhookId := r.PathValue("webhookId")
+	query := r.URL.Query()
+
+	deadLetters, err := h.Service.GetDeadLetters(ctx, organizationId, webhookId, query.Get("limit"), query.Get("offset"))
+	if err != nil {
+		if errorResponse, ok := err.(*models.ErrorResponse); ok {
+			h.Logger.Println(err)
+			utils.SendErrorResponse(w, errorResponse.StatusCode, errorResponse.Message)
+			return
+		}
+		h.Logger.Println(err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "failed to fetch dead letters")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(deadLetters); err != nil {
+		h.Logger.Println(err)
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"io"
+	"time"
+)
+
+// Attachment представляет вложение тендера или предложения: документ, содержимое которого хранится в хранилище файлов.
+type Attachment struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`     // Размер в байтах
+	Checksum    string    `json:"checksum"` // Контрольная сумма SHA-256 в шестнадцатеричной записи
+	UploadedBy  string    `json:"uploadedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// AttachmentUpload представляет загружаемый файл вложения.
+type AttachmentUpload struct {
+	FileName    string
+	ContentType string // Тип содержимого, указанный клиентом
+	Content     io.Reader
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// Auction представляет режим понижающего аукциона по тендеру: валюту торгов, расписание раундов
+// и победителя, определённого после закрытия последнего раунда.
+type Auction struct {
+	TenderID    string         `json:"tenderId"`
+	Currency    string         `json:"currency"`
+	Rounds      []AuctionRound `json:"rounds"`
+	WinnerBidID *string        `json:"winnerBidId,omitempty"`
+	FinishedAt  *time.Time     `json:"finishedAt,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
+
+// AuctionRound представляет раунд аукциона, в течение которого участники могут снижать цену.
+type AuctionRound struct {
+	Number   int        `json:"number"`
+	StartsAt time.Time  `json:"startsAt"`
+	EndsAt   time.Time  `json:"endsAt"`
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+}
+
+// AuctionRequest представляет структуру запроса для настройки аукциона по тендеру.
+type AuctionRequest struct {
+	Currency string                `json:"currency"`
+	Rounds   []AuctionRoundRequest `json:"rounds"`
+}
+
+// AuctionRoundRequest представляет время начала и окончания раунда в запросе.
+type AuctionRoundRequest struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+}
+
+// RebidRequest представляет структуру запроса для снижения цены предложения в раунде аукциона.
+type RebidRequest struct {
+	PriceAmount     float64 `json:"priceAmount"`
+	ExpectedVersion int     `json:"expectedVersion,omitempty"`
+}
+
+// AuctionRebid представляет снижение цены предложения в раунде аукциона.
+type AuctionRebid struct {
+	BidID         string    `json:"bidId"`
+	RoundNumber   int       `json:"roundNumber"`
+	PreviousPrice float64   `json:"previousPrice"`
+	PriceAmount   float64   `json:"priceAmount"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// LeaderboardEntry представляет строку таблицы лидеров аукциона. Идентификатор и название предложения
+// раскрываются только тем, кому предложение видно; Own отмечает предложения вызывающего пользователя.
+type LeaderboardEntry struct {
+	Rank        int       `json:"rank"`
+	BidID       string    `json:"bidId,omitempty"`
+	BidName     string    `json:"bidName,omitempty"`
+	PriceAmount float64   `json:"priceAmount"`
+	Own         bool      `json:"own"`
+	PricedAt    time.Time `json:"pricedAt"`
+}
+
+// Leaderboard представляет таблицу лидеров аукциона: текущий раунд и предложения по возрастанию цены.
+type Leaderboard struct {
+	TenderID     string             `json:"tenderId"`
+	Currency     string             `json:"currency"`
+	CurrentRound *AuctionRound      `json:"currentRound,omitempty"`
+	WinnerBidID  *string            `json:"winnerBidId,omitempty"`
+	Entries      []LeaderboardEntry `json:"entries"`
+}
+
+// ActiveRound возвращает раунд, идущий в момент now, или nil.
+func (a *Auction) ActiveRound(now time.Time) *AuctionRound {
+	for i := range a.Rounds {
+		round := &a.Rounds[i]
+		if round.ClosedAt == nil && !now.Before(round.StartsAt) && now.Before(round.EndsAt) {
+			return round
+		}
+	}
+	return nil
+}
+
+// Started сообщает, начался ли к моменту now первый раунд аукциона.
+func (a *Auction) Started(now time.Time) bool {
+	return len(a.Rounds) > 0 && !now.Before(a.Rounds[0].StartsAt)
+}
+
+// ValidateAuction проверяет настройки аукциона: код валюты по ISO 4217 и хотя бы один раунд,
+// первый раунд начинается позже now, раунды идут по порядку без пересечений.
+func ValidateAuction(auctionReq AuctionRequest, now time.Time) error {
+	if !currencyCodePattern.MatchString(auctionReq.Currency) {
+		return NewErrorResponse(http.StatusBadRequest, "invalid currency, must be an ISO 4217 code")
+	}
+	if len(auctionReq.Rounds) == 0 {
+		return NewErrorResponse(http.StatusBadRequest, "auction must have at least one round")
+	}
+	for i, round := range auctionReq.Rounds {
+		if i == 0 && !round.StartsAt.After(now) {
+			return NewErrorResponse(http.StatusBadRequest, "auction rounds must start in the future")
+		}
+		if i > 0 && round.StartsAt.Before(auctionReq.Rounds[i-1].EndsAt) {
+			return NewErrorResponse(http.StatusBadRequest, "auction rounds must follow each other without overlapping")
+		}
+		if !round.EndsAt.After(round.StartsAt) {
+			return NewErrorResponse(http.StatusBadRequest, "round endsAt must be after startsAt")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type (
+	AuditEntityType string // Тип объекта, над которым выполнено действие
+	AuditAction     string // Действие, записанное в журнал аудита
+)
+
+const (
+	TenderEntity AuditEntityType = "tender" // Тендер
+	BidEntity    AuditEntityType = "bid"    // Предложение
+
+	CreateTenderAction   AuditAction = "tender.create"      // Тендер создан
+	EditTenderAction     AuditAction = "tender.edit"        // Тендер отредактирован
+	StatusTenderAction   AuditAction = "tender.status"      // Изменён статус тендера
+	RollbackTenderAction AuditAction = "tender.rollback"    // Версия тендера откачена
+	LotsTenderAction     AuditAction = "tender.lots"        // Изменены лоты тендера
+	AttachTenderAction   AuditAction = "tender.attachments" // Изменены вложения тендера
+	AnswerTenderAction   AuditAction = "tender.answer"      // Дан ответ на вопрос по тендеру
+	CriteriaTenderAction AuditAction = "tender.criteria"    // Изменены критерии оценки тендера
+	OpenBidsTenderAction AuditAction = "tender.open_bids"   // Вскрыты предложения закрытого тендера
+	AuctionTenderAction  AuditAction = "tender.auction"     // Настроен аукцион по тендеру
+	RoundTenderAction    AuditAction = "tender.round"       // Закрыт раунд аукциона
+
+	CreateBidAction   AuditAction = "bid.create"      // Предложение создано
+	EditBidAction     AuditAction = "bid.edit"        // Предложение отредактировано
+	StatusBidAction   AuditAction = "bid.status"      // Изменён статус предложения
+	RollbackBidAction AuditAction = "bid.rollback"    // Версия предложения откачена
+	DecisionBidAction AuditAction = "bid.decision"    // Принято решение по предложению
+	FeedbackBidAction AuditAction = "bid.feedback"    // Оставлен отзыв на предложение
+	ScoreBidAction    AuditAction = "bid.score"       // Предложение оценено по критериям
+	RebidBidAction    AuditAction = "bid.rebid"       // Цена предложения снижена в раунде аукциона
+	AttachBidAction   AuditAction = "bid.attachments" // Изменены вложения предложения
+)
+
+// AuditEvent представляет запись журнала аудита.
+type AuditEvent struct {
+	ID             string          `json:"id"`
+	Actor          string          `json:"actor"`
+	EntityType     AuditEntityType `json:"entityType"`
+	EntityID       string          `json:"entityId"`
+	OrganizationID string          `json:"organizationId"`
+	Action         AuditAction     `json:"action"`
+	Before         json.RawMessage `json:"before"`
+	After          json.RawMessage `json:"after"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// AuditFilter описывает условия выборки записей журнала аудита.
+type AuditFilter struct {
+	EntityID string
+	Actor    string
+	From     *time.Time
+	To       *time.Time
+	ViewerID string // Возвращаются только события организаций, за которые отвечает пользователь
+	Limit    int
+	Offset   int
+}
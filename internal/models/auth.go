@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TokenRequest представляет структуру запроса на выпуск токена доступа.
+type TokenRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// TokenResponse представляет выпущенный токен доступа.
+type TokenResponse struct {
+	AccessToken string    `json:"accessToken"`
+	TokenType   string    `json:"tokenType"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
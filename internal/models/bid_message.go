@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// BidMessageSide - сторона переписки по предложению.
+type BidMessageSide string
+
+const (
+	BidSide    BidMessageSide = "bid"    // Автор предложения и ответственные его организации
+	TenderSide BidMessageSide = "tender" // Ответственные за организацию тендера
+)
+
+// maxBidMessageLength - максимальная длина сообщения в переписке по предложению в символах.
+const maxBidMessageLength = 4000
+
+// BidMessage представляет сообщение в переписке по предложению. Сообщения не входят в версии предложения
+// и сохраняются при его редактировании и откате; BidVersion - версия предложения на момент отправки.
+type BidMessage struct {
+	ID             string         `json:"id"`
+	BidID          string         `json:"bidId"`
+	AuthorUsername string         `json:"authorUsername"`
+	AuthorSide     BidMessageSide `json:"authorSide"`
+	BidVersion     int            `json:"bidVersion"`
+	Body           string         `json:"body"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+// BidMessageRequest представляет структуру запроса для отправки сообщения по предложению.
+type BidMessageRequest struct {
+	Body string `json:"body"`
+}
+
+// BidUnreadMessages представляет число непрочитанных пользователем сообщений по предложению.
+type BidUnreadMessages struct {
+	BidID    string `json:"bidId"`
+	TenderID string `json:"tenderId"`
+	Unread   int    `json:"unread"`
+}
+
+// ValidateBidMessage проверяет, что текст сообщения задан и не длиннее допустимого.
+func ValidateBidMessage(req BidMessageRequest) error {
+	if strings.TrimSpace(req.Body) == "" {
+		return NewErrorResponse(http.StatusBadRequest, "missing required fields")
+	}
+	if utf8.RuneCountInString(req.Body) > maxBidMessageLength {
+		return NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid body, must be at most %d characters", maxBidMessageLength))
+	}
+	return nil
+}
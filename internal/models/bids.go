@@ -1,6 +1,12 @@
 package models
 
-import "time"
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type (
 	BidStatus     string // Статус предложения
@@ -20,6 +26,18 @@ const (
 	RejectedBid BidDecision = "Rejected" // Предложение отклонено
 )
 
+// Допустимые значения параметра sort для списка предложений по тендеру, кроме общих для списков.
+const (
+	BidSortName          = SortName         // По названию
+	BidSortPrice         = "price"          // По возрастанию цены
+	BidSortPriceDesc     = "-price"         // По убыванию цены
+	BidSortTimeframe     = "timeframeDays"  // По возрастанию срока выполнения
+	BidSortTimeframeDesc = "-timeframeDays" // По убыванию срока выполнения
+)
+
+// currencyCodePattern - формат кода валюты по ISO 4217.
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
 // Bid представляет модель предложения.
 type Bid struct {
 	ID          string        `json:"id"`
@@ -31,6 +49,27 @@ type Bid struct {
 	AuthorId    string        `json:"authorId"`
 	Version     int           `json:"version"`
 	CreatedAt   time.Time     `json:"createdAt"`
+	BidTerms
+
+	// Attachments - вложения предложения. Заполняется в снимках версий и при изменении вложений.
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// BidTerms представляет коммерческие условия предложения.
+type BidTerms struct {
+	PriceAmount   *float64 `json:"priceAmount,omitempty"`   // Цена предложения
+	Currency      *string  `json:"currency,omitempty"`      // Код валюты по ISO 4217
+	TimeframeDays *int     `json:"timeframeDays,omitempty"` // Срок поставки или выполнения работ в днях
+	WarrantyTerms *string  `json:"warrantyTerms,omitempty"` // Гарантийные обязательства
+
+	// LotPrices - цены по лотам тендера, на которые подано предложение, в валюте предложения.
+	LotPrices []BidLotPrice `json:"lotPrices,omitempty"`
+}
+
+// BidLotPrice представляет цену предложения по отдельному лоту тендера.
+type BidLotPrice struct {
+	LotID       string  `json:"lotId"`
+	PriceAmount float64 `json:"priceAmount"`
 }
 
 // BidRequest представляет структуру запроса для создания или обновления предложения.
@@ -40,6 +79,18 @@ type BidRequest struct {
 	TenderId    string        `json:"tenderId"`
 	AuthorType  BidAuthorType `json:"authorType"`
 	AuthorId    string        `json:"authorId"`
+	BidTerms
+}
+
+// BidFilter представляет параметры сортировки и фильтрации списка предложений по тендеру.
+// Фильтр по организации выбирает предложения, поданные от имени организации её ответственными.
+type BidFilter struct {
+	ListFilter
+	AuthorTypes      []string
+	Currency         string
+	MinPrice         *float64
+	MaxPrice         *float64
+	MaxTimeframeDays *int
 }
 
 // BidReview представляет модель отзывов по предложению.
@@ -49,3 +100,64 @@ type BidReview struct {
 	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"createdAt"`
 }
+
+// BidDecisionTally представляет текущие итоги голосования по предложению.
+type BidDecisionTally struct {
+	Approvals  int `json:"approvals"`
+	Rejections int `json:"rejections"`
+	Quorum     int `json:"quorum"`
+}
+
+// BidDecisionResult представляет предложение вместе с итогами голосования по нему.
+type BidDecisionResult struct {
+	Bid
+	Tally BidDecisionTally `json:"tally"`
+}
+
+// MaxPriceAmount - верхняя граница цены (не включительно): цены хранятся в столбцах NUMERIC(18,2),
+// вмещающих не более 16 знаков до запятой.
+const MaxPriceAmount = 1e16
+
+// ValidPriceAmount сообщает, является ли amount допустимой ценой: неотрицательным числом меньше
+// MaxPriceAmount не более чем с двумя знаками после запятой. Цены хранятся с точностью до копейки,
+// и более точное значение было бы незаметно округлено при сохранении.
+func ValidPriceAmount(amount float64) bool {
+	if amount < 0 || amount >= MaxPriceAmount {
+		return false
+	}
+	formatted := strconv.FormatFloat(amount, 'f', -1, 64)
+	point := strings.IndexByte(formatted, '.')
+	return point < 0 || len(formatted)-point-1 <= 2
+}
+
+// ValidateBidTerms проверяет коммерческие условия предложения: цены допустимы (см. ValidPriceAmount)
+// и указываются вместе с кодом валюты по ISO 4217, срок выполнения положителен, каждый лот указан
+// не более одного раза.
+func ValidateBidTerms(terms BidTerms) error {
+	if (terms.PriceAmount == nil) != (terms.Currency == nil) {
+		return NewErrorResponse(http.StatusBadRequest, "priceAmount and currency must be specified together")
+	}
+	if terms.PriceAmount != nil && !ValidPriceAmount(*terms.PriceAmount) {
+		return NewErrorResponse(http.StatusBadRequest, "invalid priceAmount, must be a non-negative number less than 1e16 with at most 2 decimal places")
+	}
+	if terms.Currency != nil && !currencyCodePattern.MatchString(*terms.Currency) {
+		return NewErrorResponse(http.StatusBadRequest, "invalid currency, must be an ISO 4217 code")
+	}
+	if terms.TimeframeDays != nil && *terms.TimeframeDays <= 0 {
+		return NewErrorResponse(http.StatusBadRequest, "invalid timeframeDays, must be a positive integer")
+	}
+	if len(terms.LotPrices) > 0 && terms.Currency == nil {
+		return NewErrorResponse(http.StatusBadRequest, "currency must be specified together with lotPrices")
+	}
+	lotIds := make(map[string]bool, len(terms.LotPrices))
+	for _, lotPrice := range terms.LotPrices {
+		if lotPrice.LotID == "" || lotIds[lotPrice.LotID] {
+			return NewErrorResponse(http.StatusBadRequest, "invalid lotPrices, each lot must be specified once")
+		}
+		if !ValidPriceAmount(lotPrice.PriceAmount) {
+			return NewErrorResponse(http.StatusBadRequest, "invalid lotPrices, price must be a non-negative number less than 1e16 with at most 2 decimal places")
+		}
+		lotIds[lotPrice.LotID] = true
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Employee представляет модель сотрудника.
+type Employee struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// EmployeeRequest представляет структуру запроса для создания сотрудника.
+type EmployeeRequest struct {
+	Username  string `json:"username"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Password  string `json:"password"`
+}
@@ -1,9 +1,15 @@
 package models
 
+import (
+	"fmt"
+	"net/http"
+)
+
 // ErrorResponse описывает ошибку с кодом и сообщением.
 type ErrorResponse struct {
-	StatusCode int    `json:"-"`
-	Message    string `json:"reason"`
+	StatusCode     int    `json:"-"`
+	Message        string `json:"reason"`
+	CurrentVersion *int   `json:"currentVersion,omitempty"`
 }
 
 // NewErrorResponse создает новую ошибку с кодом и сообщением.
@@ -13,6 +19,14 @@ func NewErrorResponse(statusCode int, message string) *ErrorResponse {
 		Message:    message}
 }
 
+// NewVersionConflictError создает ошибку конфликта версий, содержащую текущую версию объекта.
+func NewVersionConflictError(currentVersion int) *ErrorResponse {
+	return &ErrorResponse{
+		StatusCode:     http.StatusConflict,
+		Message:        fmt.Sprintf("version conflict: current version is %d", currentVersion),
+		CurrentVersion: &currentVersion}
+}
+
 // Реализация метода Error() для удовлетворения интерфейса error.
 func (e *ErrorResponse) Error() string {
 	return e.Message
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+type CriterionKind string // Вид критерия оценки предложений
+
+const (
+	PriceCriterion     CriterionKind = "price"     // Цена предложения: чем ниже, тем выше оценка
+	TimeframeCriterion CriterionKind = "timeframe" // Срок выполнения: чем короче, тем выше оценка
+	CustomCriterion    CriterionKind = "custom"    // Числовой критерий, который оценивают ответственные
+
+	// MaxCriterionScore - максимальная оценка предложения по одному критерию.
+	MaxCriterionScore = 100
+)
+
+// EvaluationCriterion представляет взвешенный критерий оценки предложений по тендеру.
+type EvaluationCriterion struct {
+	ID        string        `json:"id"`
+	TenderID  string        `json:"tenderId"`
+	Name      string        `json:"name"`
+	Kind      CriterionKind `json:"kind"`
+	Weight    float64       `json:"weight"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// EvaluationCriterionRequest представляет структуру запроса для создания критерия оценки.
+type EvaluationCriterionRequest struct {
+	Name   string        `json:"name"`
+	Kind   CriterionKind `json:"kind"`
+	Weight float64       `json:"weight"`
+}
+
+// BidScore представляет оценку предложения ответственным по одному критерию.
+type BidScore struct {
+	BidID       string    `json:"bidId"`
+	CriterionID string    `json:"criterionId"`
+	UserID      string    `json:"userId"`
+	Score       float64   `json:"score"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// BidScoreRequest представляет оценку предложения по одному критерию в запросе.
+type BidScoreRequest struct {
+	CriterionID string  `json:"criterionId"`
+	Score       float64 `json:"score"`
+}
+
+// CriterionResult представляет оценку предложения по критерию в сравнительной матрице.
+// Score равен nil, если по критерию нет данных: не указана цена, срок или нет ни одной оценки.
+type CriterionResult struct {
+	CriterionID string   `json:"criterionId"`
+	Score       *float64 `json:"score"`
+	ScoresCount int      `json:"scoresCount,omitempty"` // Число оценок ответственных для пользовательского критерия
+}
+
+// BidEvaluation представляет строку сравнительной матрицы: итоговую оценку и место предложения.
+type BidEvaluation struct {
+	BidID      string            `json:"bidId"`
+	BidName    string            `json:"bidName"`
+	Rank       int               `json:"rank"`
+	TotalScore float64           `json:"totalScore"`
+	Criteria   []CriterionResult `json:"criteria"`
+}
+
+// TenderEvaluation представляет сравнительную матрицу опубликованных предложений по тендеру.
+type TenderEvaluation struct {
+	TenderID string                `json:"tenderId"`
+	Criteria []EvaluationCriterion `json:"criteria"`
+	Bids     []BidEvaluation       `json:"bids"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Допустимые значения параметра sort, общие для списков тендеров и предложений.
+const (
+	SortName          = "name"       // По названию
+	SortCreatedAt     = "createdAt"  // От старых к новым
+	SortCreatedAtDesc = "-createdAt" // От новых к старым
+	SortVersion       = "version"    // По возрастанию версии
+	SortStatus        = "status"     // По статусу
+)
+
+// TenderSorts - допустимые значения сортировки списков тендеров.
+var TenderSorts = []string{SortName, SortCreatedAt, SortCreatedAtDesc, SortVersion, SortStatus}
+
+// BidSorts - допустимые значения сортировки списка предложений по тендеру.
+var BidSorts = []string{SortName, SortCreatedAt, SortCreatedAtDesc, SortVersion, SortStatus,
+	BidSortPrice, BidSortPriceDesc, BidSortTimeframe, BidSortTimeframeDesc}
+
+// TenderStatuses - допустимые значения фильтра по статусу тендера.
+var TenderStatuses = []string{string(CreatedTender), string(PublishedTender), string(ClosedTender)}
+
+// BidStatuses - допустимые значения фильтра по статусу предложения.
+var BidStatuses = []string{string(CreatedBid), string(PublishedBid), string(CanceledBid), string(ApprovedBid), string(RejectedBid)}
+
+// BidAuthorTypes - допустимые значения фильтра по типу автора предложения.
+var BidAuthorTypes = []string{string(Organization), string(User)}
+
+// ListFilter представляет общие параметры сортировки и фильтрации списков тендеров и предложений.
+// Пустые списки и nil не ограничивают выборку, значения одного фильтра объединяются через ИЛИ.
+type ListFilter struct {
+	Sort            string
+	Statuses        []string
+	OrganizationIDs []string
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+}
+
+// TenderFilter представляет параметры сортировки и фильтрации списков тендеров.
+type TenderFilter struct {
+	ListFilter
+	ServiceTypes []string
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type OrganizationType string // Тип организации
+
+const (
+	IEOrganization  OrganizationType = "IE"  // Индивидуальный предприниматель
+	LLCOrganization OrganizationType = "LLC" // Общество с ограниченной ответственностью
+	JSCOrganization OrganizationType = "JSC" // Акционерное общество
+)
+
+// OrganizationInfo представляет модель организации.
+type OrganizationInfo struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Type        OrganizationType `json:"type"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+// OrganizationRequest представляет структуру запроса для создания организации.
+type OrganizationRequest struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Type        OrganizationType `json:"type"`
+}
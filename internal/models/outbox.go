@@ -0,0 +1,18 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxMessage представляет событие, сохранённое в таблицу outbox в транзакции изменения
+// и ожидающее отправки во внешнюю систему. IdempotencyKey совпадает с идентификатором события
+// и одинаков для всех повторных отправок.
+type OutboxMessage struct {
+	ID             int64           `json:"-"`
+	IdempotencyKey string          `json:"idempotencyKey"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	Attempts       int             `json:"-"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Page представляет параметры страницы списка. При постраничном выводе по курсору (Cursor)
+// Offset не используется, а After содержит значения ключей сортировки последнего элемента
+// предыдущей страницы; для первой страницы After пуст.
+type Page struct {
+	Limit  int
+	Offset int
+	Cursor bool
+	After  []any
+}
+
+// Paged представляет страницу списка. Next содержит значения ключей сортировки последнего элемента
+// страницы, если за ней есть ещё элементы, и nil для последней страницы.
+type Paged[T any] struct {
+	Items []T
+	Next  []any
+}
+
+// CursorPage представляет ответ на запрос страницы списка по курсору. NextCursor пуст на последней странице.
+type CursorPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
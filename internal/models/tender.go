@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
 
 type (
 	TenderServiceType string // Тип услуги для тендера
@@ -28,6 +31,18 @@ type Tender struct {
 	Version         int32             `json:"version"`
 	CreatedAt       time.Time         `json:"createdAt"`
 	CreatorUsername string            `json:"-"`
+
+	SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty"` // Срок подачи предложений
+	DecisionDeadline   *time.Time `json:"decisionDeadline,omitempty"`   // Срок принятия решения
+
+	Sealed        bool       `json:"sealed"`                  // Закрытый режим: содержимое предложений скрыто до вскрытия
+	BidsOpeningAt *time.Time `json:"bidsOpeningAt,omitempty"` // Назначенное время вскрытия предложений
+	BidsOpenedAt  *time.Time `json:"bidsOpenedAt,omitempty"`  // Фактическое время вскрытия предложений
+
+	// Lots - лоты тендера. Заполняется в снимках версий и при изменении лотов.
+	Lots []TenderLot `json:"lots,omitempty"`
+	// Attachments - вложения тендера. Заполняется в снимках версий и при изменении вложений.
+	Attachments []Attachment `json:"attachments,omitempty"`
 }
 
 // TenderRequest представляет структуру запроса для создания или обновления тендера.
@@ -37,4 +52,36 @@ type TenderRequest struct {
 	ServiceType     TenderServiceType `json:"serviceType"`
 	OrganizationID  string            `json:"organizationId"`
 	CreatorUsername string            `json:"creatorUsername"`
+
+	SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty"`
+	DecisionDeadline   *time.Time `json:"decisionDeadline,omitempty"`
+
+	Sealed        bool       `json:"sealed"`
+	BidsOpeningAt *time.Time `json:"bidsOpeningAt,omitempty"`
+}
+
+// SealedBids представляет список предложений по тендеру в закрытом режиме до вскрытия:
+// ответственным за тендер доступно только число опубликованных предложений, автору - свои предложения.
+type SealedBids struct {
+	Sealed        bool       `json:"sealed"`
+	BidsOpeningAt *time.Time `json:"bidsOpeningAt,omitempty"`
+	BidsCount     int        `json:"bidsCount"`
+	Bids          []Bid      `json:"bids"`
+}
+
+// BidsSealed сообщает, скрыты ли предложения по тендеру в момент now. Предложения закрытого тендера
+// вскрываются при закрытии тендера или по наступлении назначенного времени вскрытия.
+func (t *Tender) BidsSealed(now time.Time) bool {
+	if !t.Sealed || t.BidsOpenedAt != nil || t.Status == ClosedTender {
+		return false
+	}
+	return t.BidsOpeningAt == nil || now.Before(*t.BidsOpeningAt)
+}
+
+// ValidateTenderDeadlines проверяет, что срок принятия решения не раньше срока подачи предложений.
+func ValidateTenderDeadlines(submissionDeadline, decisionDeadline *time.Time) error {
+	if submissionDeadline != nil && decisionDeadline != nil && decisionDeadline.Before(*submissionDeadline) {
+		return NewErrorResponse(http.StatusBadRequest, "decisionDeadline must not be earlier than submissionDeadline")
+	}
+	return nil
 }
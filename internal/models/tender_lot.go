@@ -0,0 +1,50 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// TenderLot представляет лот тендера: позицию закупки с количеством, единицей измерения и максимальным бюджетом.
+type TenderLot struct {
+	ID          string    `json:"id"`
+	TenderID    string    `json:"tenderId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Quantity    float64   `json:"quantity"`
+	Unit        string    `json:"unit"`
+	MaxBudget   *float64  `json:"maxBudget,omitempty"` // Максимальный бюджет лота
+	Currency    *string   `json:"currency,omitempty"`  // Код валюты бюджета по ISO 4217
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// TenderLotRequest представляет структуру запроса для создания лота тендера.
+type TenderLotRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Quantity    float64  `json:"quantity"`
+	Unit        string   `json:"unit"`
+	MaxBudget   *float64 `json:"maxBudget,omitempty"`
+	Currency    *string  `json:"currency,omitempty"`
+}
+
+// ValidateTenderLot проверяет поля лота: название и единица измерения заданы, количество положительно,
+// бюджет неотрицателен и указывается вместе с кодом валюты.
+func ValidateTenderLot(lot *TenderLot) error {
+	if lot.Name == "" || lot.Unit == "" {
+		return NewErrorResponse(http.StatusBadRequest, "missing required fields")
+	}
+	if lot.Quantity <= 0 {
+		return NewErrorResponse(http.StatusBadRequest, "invalid quantity, must be a positive number")
+	}
+	if (lot.MaxBudget == nil) != (lot.Currency == nil) {
+		return NewErrorResponse(http.StatusBadRequest, "maxBudget and currency must be specified together")
+	}
+	if lot.MaxBudget != nil && *lot.MaxBudget < 0 {
+		return NewErrorResponse(http.StatusBadRequest, "invalid maxBudget, must be a non-negative number")
+	}
+	if lot.Currency != nil && !currencyCodePattern.MatchString(*lot.Currency) {
+		return NewErrorResponse(http.StatusBadRequest, "invalid currency, must be an ISO 4217 code")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Максимальная длина вопроса и ответа по тендеру в символах.
+const (
+	maxQuestionLength = 2000
+	maxAnswerLength   = 4000
+)
+
+// TenderQuestion представляет вопрос участника по тендеру и ответ организации тендера.
+// Автор вопроса не раскрывается: пользователь узнаёт только, что вопрос задал он сам.
+type TenderQuestion struct {
+	ID         string     `json:"id"`
+	TenderID   string     `json:"tenderId"`
+	Question   string     `json:"question"`
+	Answer     *string    `json:"answer,omitempty"`
+	AskedByMe  bool       `json:"askedByMe"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	AnsweredAt *time.Time `json:"answeredAt,omitempty"`
+}
+
+// TenderQuestionRequest представляет структуру запроса для вопроса по тендеру.
+type TenderQuestionRequest struct {
+	Question string `json:"question"`
+}
+
+// TenderAnswerRequest представляет структуру запроса для ответа на вопрос по тендеру.
+type TenderAnswerRequest struct {
+	Answer string `json:"answer"`
+}
+
+// ValidateTenderQuestion проверяет, что вопрос задан и не длиннее допустимого.
+func ValidateTenderQuestion(req TenderQuestionRequest) error {
+	return validateQuestionText("question", req.Question, maxQuestionLength)
+}
+
+// ValidateTenderAnswer проверяет, что ответ задан и не длиннее допустимого.
+func ValidateTenderAnswer(req TenderAnswerRequest) error {
+	return validateQuestionText("answer", req.Answer, maxAnswerLength)
+}
+
+// validateQuestionText проверяет текст вопроса или ответа.
+func validateQuestionText(field, text string, maxLength int) error {
+	if strings.TrimSpace(text) == "" {
+		return NewErrorResponse(http.StatusBadRequest, "missing required fields")
+	}
+	if utf8.RuneCountInString(text) > maxLength {
+		return NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid %s, must be at most %d characters", field, maxLength))
+	}
+	return nil
+}
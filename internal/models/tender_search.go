@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxSearchQueryLength - максимальная длина поискового запроса.
+const maxSearchQueryLength = 256
+
+// TenderSearch представляет параметры поиска тендеров. Пустые списки и nil не ограничивают выборку.
+// Бюджет тендера - сумма максимальных бюджетов его лотов в валюте Currency.
+type TenderSearch struct {
+	Query           string
+	Statuses        []string
+	OrganizationIDs []string
+	ServiceTypes    []string
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	BudgetFrom      *float64
+	BudgetTo        *float64
+	Currency        string
+}
+
+// TenderSearchHit представляет найденный тендер и его релевантность запросу.
+type TenderSearchHit struct {
+	Tender
+	Rank float64 `json:"rank,omitempty"`
+}
+
+// TenderFacets представляет число найденных тендеров по каждому типу услуги и статусу.
+// Счётчики измерения учитывают все фильтры, кроме фильтра по самому измерению.
+type TenderFacets struct {
+	ServiceType map[string]int `json:"serviceType"`
+	Status      map[string]int `json:"status"`
+}
+
+// TenderSearchResult представляет страницу результатов поиска тендеров.
+type TenderSearchResult struct {
+	Total   int               `json:"total"`
+	Tenders []TenderSearchHit `json:"tenders"`
+	Facets  TenderFacets      `json:"facets"`
+}
+
+// ValidateTenderSearch проверяет параметры поиска тендеров: длину запроса, статусы и типы услуг,
+// порядок границ дат и бюджета. Фильтр по бюджету требует код валюты по ISO 4217.
+func ValidateTenderSearch(search TenderSearch) error {
+	if len([]rune(search.Query)) > maxSearchQueryLength {
+		return NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("query must not exceed %d characters", maxSearchQueryLength))
+	}
+	for _, status := range search.Statuses {
+		switch TenderStatus(status) {
+		case CreatedTender, PublishedTender, ClosedTender:
+		default:
+			return NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("unsupported status: %s", status))
+		}
+	}
+	for _, serviceType := range search.ServiceTypes {
+		switch TenderServiceType(serviceType) {
+		case Construction, Delivery, Manufacture:
+		default:
+			return NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("unsupported service type: %s", serviceType))
+		}
+	}
+	if search.CreatedFrom != nil && search.CreatedTo != nil && search.CreatedFrom.After(*search.CreatedTo) {
+		return NewErrorResponse(http.StatusBadRequest, "createdFrom must not be after createdTo")
+	}
+	if search.BudgetFrom != nil || search.BudgetTo != nil {
+		if !currencyCodePattern.MatchString(search.Currency) {
+			return NewErrorResponse(http.StatusBadRequest, "budget filter requires currency, must be an ISO 4217 code")
+		}
+	}
+	if search.BudgetFrom != nil && search.BudgetTo != nil && *search.BudgetFrom > *search.BudgetTo {
+		return NewErrorResponse(http.StatusBadRequest, "budgetFrom must not be greater than budgetTo")
+	}
+	return nil
+}
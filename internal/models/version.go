@@ -0,0 +1,15 @@
+package models
+
+// FieldChange описывает изменение одного поля между двумя версиями объекта.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// VersionDiff представляет разницу между двумя версиями тендера или предложения.
+type VersionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
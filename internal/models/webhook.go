@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Webhook представляет адрес, на который сервис отправляет события тендеров и предложений организации.
+// Пустой список EventTypes означает подписку на все события. Секрет возвращается только при создании.
+type Webhook struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"eventTypes"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// WebhookRequest представляет структуру запроса для регистрации вебхука. Если секрет не указан,
+// он генерируется сервисом.
+type WebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Secret     string   `json:"secret"`
+}
+
+// WebhookDelivery представляет доставку события на адрес вебхука, ожидающую очередной попытки.
+type WebhookDelivery struct {
+	ID        string
+	WebhookID string
+	EventID   string
+	EventType string
+	Payload   json.RawMessage
+	Attempts  int
+	CreatedAt time.Time
+	URL       string
+	Secret    string
+}
+
+// WebhookDeadLetter представляет доставку, от которой сервис отказался после исчерпания попыток.
+type WebhookDeadLetter struct {
+	ID        string          `json:"id"`
+	WebhookID string          `json:"webhookId"`
+	EventID   string          `json:"eventId"`
+	EventType string          `json:"eventType"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	LastError *string         `json:"lastError,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	FailedAt  time.Time       `json:"failedAt"`
+}
+
+// ValidateWebhookURL проверяет, что адрес вебхука - абсолютный URL со схемой http или https,
+// не указывающий на локальный узел или внутреннюю сеть. Адреса, заданные именем, дополнительно
+// проверяются при каждом подключении, после разрешения имени.
+func ValidateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return NewErrorResponse(http.StatusBadRequest, "invalid url, must be an absolute http or https URL")
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return NewErrorResponse(http.StatusBadRequest, "invalid url, must not point to a local or private address")
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicWebhookIP(ip) {
+		return NewErrorResponse(http.StatusBadRequest, "invalid url, must not point to a local or private address")
+	}
+	return nil
+}
+
+// IsPublicWebhookIP сообщает, можно ли отправлять вебхуки на адрес ip: запрещены локальные,
+// частные (RFC 1918, RFC 4193), link-local, в том числе 169.254.169.254, и групповые адреса.
+func IsPublicWebhookIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
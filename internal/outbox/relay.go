@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/repository"
+)
+
+const (
+	// relayLockKey - ключ advisory-блокировки, под которой ретранслируются сообщения.
+	// Пока один ретранслятор держит блокировку, остальные пропускают запуск, поэтому
+	// сообщения отправляются по одному в порядке идентификаторов.
+	relayLockKey int64 = 7_011_002
+
+	// relayBatchSize - максимальное число сообщений, отправляемых за одну транзакцию.
+	relayBatchSize = 100
+
+	// MaxAttempts - число неудачных попыток отправки, после которого сообщение переносится
+	// в таблицу недоставленных и перестаёт задерживать следующие сообщения.
+	MaxAttempts = 10
+)
+
+// unitOfWork - транзакции и advisory-блокировки, которыми пользуется ретранслятор; реализуется db.UnitOfWork.
+type unitOfWork interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	TryAdvisoryLock(ctx context.Context, key int64) (bool, error)
+}
+
+// Relay периодически отправляет неотправленные сообщения outbox в Sink.
+// Сообщение отмечается отправленным только после успешной отправки, поэтому каждое сообщение
+// доставляется хотя бы один раз.
+type Relay struct {
+	Repo     repository.OutboxRepository
+	Sink     Sink
+	Interval time.Duration
+	Logger   *log.Logger
+	uow      unitOfWork
+}
+
+// NewRelay создаёт новый экземпляр Relay.
+func NewRelay(repo repository.OutboxRepository, sink Sink, uow *db.UnitOfWork, interval time.Duration, logger *log.Logger) *Relay {
+	return &Relay{
+		Repo:     repo,
+		Sink:     sink,
+		Interval: interval,
+		Logger:   logger,
+		uow:      uow,
+	}
+}
+
+// Run запускает ретранслятор и блокируется до отмены контекста.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.relayPending(ctx)
+		}
+	}
+}
+
+// relayPending отправляет накопившиеся сообщения пачками, пока они не закончатся или отправка не завершится ошибкой.
+func (r *Relay) relayPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		sent, more, err := r.RelayBatch(ctx)
+		if err != nil {
+			r.Logger.Printf("outbox: failed to relay messages: %v", err)
+			return
+		}
+		if sent > 0 {
+			r.Logger.Printf("outbox: relayed %d messages", sent)
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// RelayBatch отправляет в Sink одну пачку сообщений в порядке идентификаторов и возвращает число отправленных
+// сообщений и признак того, что неотправленные сообщения могут остаться. Идентификаторы выдаются при вставке,
+// а параллельные транзакции фиксируются в своём порядке, поэтому порядок отправки может отличаться от порядка
+// изменений. Отправка пачки прерывается на первой ошибке Sink; ошибка сохраняется у сообщения, и оно
+// отправляется повторно при следующем запуске. После MaxAttempts неудачных попыток сообщение переносится
+// в таблицу недоставленных, и отправка продолжается со следующего. Если блокировку держит другой
+// ретранслятор, ничего не отправляется.
+func (r *Relay) RelayBatch(ctx context.Context) (int, bool, error) {
+	var sent int
+	var more bool
+	err := r.uow.WithinTx(ctx, func(ctx context.Context) error {
+		acquired, err := r.uow.TryAdvisoryLock(ctx, relayLockKey)
+		if err != nil || !acquired {
+			return err
+		}
+
+		messages, err := r.Repo.LockPending(ctx, relayBatchSize)
+		if err != nil {
+			return err
+		}
+
+		var published []int64
+		processed := 0
+		for _, message := range messages {
+			if err = r.Sink.Publish(ctx, message); err != nil {
+				message.Attempts++
+				r.Logger.Printf("outbox: failed to publish message %s (attempt %d): %v", message.IdempotencyKey, message.Attempts, err)
+				if message.Attempts >= MaxAttempts {
+					if err = r.Repo.DeadLetter(ctx, message, err.Error()); err != nil {
+						return err
+					}
+					processed++
+					continue
+				}
+				if err = r.Repo.RecordFailure(ctx, message.ID, err.Error()); err != nil {
+					return err
+				}
+				break
+			}
+			published = append(published, message.ID)
+			processed++
+		}
+
+		sent = len(published)
+		more = len(messages) == relayBatchSize && processed == len(messages)
+		return r.Repo.MarkPublished(ctx, published, time.Now().UTC())
+	})
+	return sent, more, err
+}
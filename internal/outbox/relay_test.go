@@ -0,0 +1,243 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/events"
+	"github.com/senyabanana/tender-service/internal/models"
+)
+
+// memoryUnitOfWork - unitOfWork без базы данных: fn выполняется сразу, блокировка всегда берётся.
+type memoryUnitOfWork struct{}
+
+func (memoryUnitOfWork) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (memoryUnitOfWork) TryAdvisoryLock(context.Context, int64) (bool, error) {
+	return true, nil
+}
+
+// memoryOutbox - OutboxRepository в памяти. failMarkPublished задаёт число вызовов MarkPublished,
+// завершающихся ошибкой, как если бы транзакция ретранслятора откатилась после отправки.
+type memoryOutbox struct {
+	messages          []models.OutboxMessage
+	published         map[int64]bool
+	lastErrors        map[int64]string
+	deadLetters       []models.OutboxMessage
+	failMarkPublished int
+}
+
+func newMemoryOutbox(keys ...string) *memoryOutbox {
+	repo := &memoryOutbox{published: make(map[int64]bool), lastErrors: make(map[int64]string)}
+	for i, key := range keys {
+		repo.messages = append(repo.messages, models.OutboxMessage{
+			ID:             int64(i + 1),
+			IdempotencyKey: key,
+			EventType:      "tender.created",
+			CreatedAt:      time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+		})
+	}
+	return repo
+}
+
+func (r *memoryOutbox) SaveEvent(context.Context, events.Event) error {
+	return errors.New("not implemented")
+}
+
+func (r *memoryOutbox) LockPending(_ context.Context, limit int) ([]models.OutboxMessage, error) {
+	var pending []models.OutboxMessage
+	for _, message := range r.messages {
+		if !r.published[message.ID] && len(pending) < limit {
+			pending = append(pending, message)
+		}
+	}
+	return pending, nil
+}
+
+func (r *memoryOutbox) MarkPublished(_ context.Context, ids []int64, _ time.Time) error {
+	if r.failMarkPublished > 0 {
+		r.failMarkPublished--
+		return errors.New("connection reset")
+	}
+	for _, id := range ids {
+		r.published[id] = true
+	}
+	return nil
+}
+
+func (r *memoryOutbox) RecordFailure(_ context.Context, id int64, lastError string) error {
+	for i := range r.messages {
+		if r.messages[i].ID == id {
+			r.messages[i].Attempts++
+			r.lastErrors[id] = lastError
+		}
+	}
+	return nil
+}
+
+func (r *memoryOutbox) DeadLetter(_ context.Context, message models.OutboxMessage, lastError string) error {
+	for i := range r.messages {
+		if r.messages[i].ID == message.ID {
+			r.messages = append(r.messages[:i], r.messages[i+1:]...)
+			r.deadLetters = append(r.deadLetters, message)
+			r.lastErrors[message.ID] = lastError
+			return nil
+		}
+	}
+	return errors.New("message not found")
+}
+
+// message возвращает сообщение с ключом key.
+func (r *memoryOutbox) message(t *testing.T, key string) models.OutboxMessage {
+	t.Helper()
+	for _, message := range r.messages {
+		if message.IdempotencyKey == key {
+			return message
+		}
+	}
+	t.Fatalf("message %s not found", key)
+	return models.OutboxMessage{}
+}
+
+// flakySink - Sink, отклоняющий первые отправки сообщений с заданными ключами.
+type flakySink struct {
+	*MemorySink
+	failures map[string]int
+}
+
+func (s *flakySink) Publish(ctx context.Context, message models.OutboxMessage) error {
+	if s.failures[message.IdempotencyKey] > 0 {
+		s.failures[message.IdempotencyKey]--
+		return errors.New("sink unavailable")
+	}
+	return s.MemorySink.Publish(ctx, message)
+}
+
+func newTestRelay(repo *memoryOutbox, sink Sink) *Relay {
+	return &Relay{Repo: repo, Sink: sink, Logger: log.New(io.Discard, "", 0), uow: memoryUnitOfWork{}}
+}
+
+// receivedKeys возвращает ключи идемпотентности полученных сообщений в порядке получения.
+func receivedKeys(sink *MemorySink) []string {
+	var keys []string
+	for _, message := range sink.Messages() {
+		keys = append(keys, message.IdempotencyKey)
+	}
+	return keys
+}
+
+func TestRelayBatchRedeliversAfterSinkFailure(t *testing.T) {
+	repo := newMemoryOutbox("first", "second", "third")
+	sink := &flakySink{MemorySink: NewMemorySink(), failures: map[string]int{"second": 1}}
+	relay := newTestRelay(repo, sink)
+
+	sent, more, err := relay.RelayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("RelayBatch() error = %v", err)
+	}
+	if sent != 1 || more {
+		t.Errorf("RelayBatch() = %d, %v, want 1, false", sent, more)
+	}
+	// Отправка прерывается на отклонённом сообщении, чтобы не нарушить порядок.
+	if got := receivedKeys(sink.MemorySink); !reflect.DeepEqual(got, []string{"first"}) {
+		t.Errorf("received %v, want [first]", got)
+	}
+	failed := repo.message(t, "second")
+	if repo.published[failed.ID] {
+		t.Error("failed message is marked published")
+	}
+	if failed.Attempts != 1 {
+		t.Errorf("attempts = %d, want 1", failed.Attempts)
+	}
+	if repo.lastErrors[failed.ID] != "sink unavailable" {
+		t.Errorf("last error = %q, want %q", repo.lastErrors[failed.ID], "sink unavailable")
+	}
+
+	sent, _, err = relay.RelayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("RelayBatch() error = %v", err)
+	}
+	if sent != 2 {
+		t.Errorf("RelayBatch() sent = %d, want 2", sent)
+	}
+	if got, want := receivedKeys(sink.MemorySink), []string{"first", "second", "third"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v, want %v", got, want)
+	}
+	for _, message := range repo.messages {
+		if !repo.published[message.ID] {
+			t.Errorf("message %s is not marked published", message.IdempotencyKey)
+		}
+	}
+	if attempts := repo.message(t, "second").Attempts; attempts != 1 {
+		t.Errorf("attempts after redelivery = %d, want 1", attempts)
+	}
+}
+
+func TestRelayBatchDropsDuplicates(t *testing.T) {
+	repo := newMemoryOutbox("first", "second")
+	// Сообщения отправлены, но отметка об отправке не сохранилась, и следующий запуск отправит их повторно.
+	repo.failMarkPublished = 1
+	sink := NewMemorySink()
+	relay := newTestRelay(repo, sink)
+
+	if _, _, err := relay.RelayBatch(context.Background()); err == nil {
+		t.Fatal("RelayBatch() error = nil, want error")
+	}
+	if pending, _ := repo.LockPending(context.Background(), relayBatchSize); len(pending) != 2 {
+		t.Fatalf("pending = %d, want 2", len(pending))
+	}
+
+	sent, _, err := relay.RelayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("RelayBatch() error = %v", err)
+	}
+	if sent != 2 {
+		t.Errorf("RelayBatch() sent = %d, want 2", sent)
+	}
+	if got, want := receivedKeys(sink), []string{"first", "second"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v, want %v", got, want)
+	}
+}
+
+func TestRelayBatchDeadLettersAfterMaxAttempts(t *testing.T) {
+	repo := newMemoryOutbox("poison", "next")
+	sink := &flakySink{MemorySink: NewMemorySink(), failures: map[string]int{"poison": MaxAttempts}}
+	relay := newTestRelay(repo, sink)
+
+	for run := 1; run < MaxAttempts; run++ {
+		if sent, _, err := relay.RelayBatch(context.Background()); err != nil || sent != 0 {
+			t.Fatalf("run %d: RelayBatch() = %d, %v, want 0, nil", run, sent, err)
+		}
+	}
+	if attempts := repo.message(t, "poison").Attempts; attempts != MaxAttempts-1 {
+		t.Fatalf("attempts = %d, want %d", attempts, MaxAttempts-1)
+	}
+
+	// Последняя неудачная попытка переносит сообщение в недоставленные, и отправка продолжается.
+	sent, _, err := relay.RelayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("RelayBatch() error = %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("RelayBatch() sent = %d, want 1", sent)
+	}
+	if got := receivedKeys(sink.MemorySink); !reflect.DeepEqual(got, []string{"next"}) {
+		t.Errorf("received %v, want [next]", got)
+	}
+	if len(repo.deadLetters) != 1 || repo.deadLetters[0].IdempotencyKey != "poison" {
+		t.Fatalf("dead letters = %+v, want [poison]", repo.deadLetters)
+	}
+	if attempts := repo.deadLetters[0].Attempts; attempts != MaxAttempts {
+		t.Errorf("dead letter attempts = %d, want %d", attempts, MaxAttempts)
+	}
+	if pending, _ := repo.LockPending(context.Background(), relayBatchSize); len(pending) != 0 {
+		t.Errorf("pending = %d, want 0", len(pending))
+	}
+}
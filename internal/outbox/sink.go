@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/senyabanana/tender-service/internal/models"
+)
+
+// Sink - внешняя система, в которую ретранслятор отправляет сообщения outbox. Сообщение может быть
+// отправлено повторно, если ретранслятор не успел отметить его отправленным, поэтому получатель
+// должен отбрасывать повторы по IdempotencyKey.
+type Sink interface {
+	Publish(ctx context.Context, message models.OutboxMessage) error
+}
+
+// MemorySink - реализация Sink, хранящая сообщения в памяти и отбрасывающая повторы.
+// Предназначена для тестов.
+type MemorySink struct {
+	mu       sync.Mutex
+	seen     map[string]bool
+	messages []models.OutboxMessage
+}
+
+// NewMemorySink создаёт новый экземпляр MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{seen: make(map[string]bool)}
+}
+
+// Publish сохраняет сообщение, если сообщение с тем же ключом идемпотентности ещё не получено.
+func (s *MemorySink) Publish(_ context.Context, message models.OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[message.IdempotencyKey] {
+		return nil
+	}
+	s.seen[message.IdempotencyKey] = true
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+// Messages возвращает полученные сообщения в порядке получения.
+func (s *MemorySink) Messages() []models.OutboxMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.OutboxMessage(nil), s.messages...)
+}
+
+// FileSink - реализация Sink, дописывающая сообщения в файл по одному JSON-объекту на строку.
+// Повторы не отбрасываются: их отбрасывает читатель файла по ключу идемпотентности.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink открывает файл path для дописывания, создавая его при необходимости.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Publish дописывает сообщение в файл и сбрасывает его на диск.
+func (s *FileSink) Publish(_ context.Context, message models.OutboxMessage) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err = s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Close закрывает файл.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
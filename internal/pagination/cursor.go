@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidCursor возвращается, если курсор повреждён, подделан или выдан для другого списка.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor - курсор постраничного вывода: список и сортировка, для которых он выдан, и значения ключей
+// сортировки последнего элемента страницы. Значения - строки, числа или null.
+type Cursor struct {
+	List  string `json:"l"`
+	Sort  string `json:"s,omitempty"`
+	After []any  `json:"a"`
+}
+
+// Signer кодирует курсоры в непрозрачные строки, подписанные HMAC-SHA256, и проверяет их подпись.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner создаёт новый экземпляр Signer с ключом подписи secret.
+func NewSigner(secret string) *Signer {
+	return &Signer{key: []byte(secret)}
+}
+
+// Encode кодирует курсор в строку вида "<данные>.<подпись>" в base64url без выравнивания.
+func (s *Signer) Encode(cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+// Decode проверяет подпись курсора и декодирует его. Курсор должен быть выдан для списка list
+// с сортировкой sort.
+func (s *Signer) Decode(token, list, sort string) (Cursor, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err = json.Unmarshal(payload, &cursor); err != nil || cursor.List != list || cursor.Sort != sort {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// sign вычисляет подпись закодированных данных курсора.
+func (s *Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// attachmentColumns - список столбцов таблиц вложений в порядке, ожидаемом scanAttachment.
+const attachmentColumns = `id, file_name, content_type, size, checksum, uploaded_by, created_at`
+
+// attachmentTable описывает таблицу вложений сущности: имя таблицы и столбец с идентификатором владельца.
+type attachmentTable struct {
+	name        string
+	ownerColumn string
+}
+
+// Таблицы вложений тендеров и предложений.
+var (
+	tenderAttachments = attachmentTable{name: "tender_attachment", ownerColumn: "tender_id"}
+	bidAttachments    = attachmentTable{name: "bid_attachment", ownerColumn: "bid_id"}
+)
+
+// snapshot возвращает подзапрос, собирающий вложения владельца ownerIdExpr в JSON-массив для снимка версии.
+func (t attachmentTable) snapshot(ownerIdExpr string) string {
+	return `(SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'id', a.id, 'fileName', a.file_name, 'contentType', a.content_type, 'size', a.size,
+			'checksum', a.checksum, 'uploadedBy', a.uploaded_by,
+			'createdAt', a.created_at AT TIME ZONE 'UTC') ORDER BY a.created_at, a.id), '[]'::jsonb)
+		FROM ` + t.name + ` a WHERE a.` + t.ownerColumn + ` = ` + ownerIdExpr + `)`
+}
+
+// scanAttachment считывает вложение из строки результата, выбранной по attachmentColumns.
+func scanAttachment(row pgx.Row) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.Checksum,
+		&attachment.UploadedBy,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// list возвращает вложения владельца в порядке загрузки.
+func (t attachmentTable) list(ctx context.Context, conn db.DBTX, ownerId string) ([]models.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM ` + t.name + ` WHERE ` + t.ownerColumn + ` = $1 ORDER BY created_at, id`
+	rows, err := conn.Query(ctx, query, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+	return attachments, rows.Err()
+}
+
+// get возвращает вложение владельца.
+func (t attachmentTable) get(ctx context.Context, conn db.DBTX, ownerId, attachmentId string) (*models.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM ` + t.name + ` WHERE ` + t.ownerColumn + ` = $1 AND id = $2`
+	return scanAttachment(conn.QueryRow(ctx, query, ownerId, attachmentId))
+}
+
+// insert сохраняет вложение владельца.
+func (t attachmentTable) insert(ctx context.Context, conn db.DBTX, ownerId string, attachment *models.Attachment) error {
+	insertQuery := `INSERT INTO ` + t.name + ` (` + t.ownerColumn + `, ` + attachmentColumns + `)
+	                VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := conn.Exec(
+		ctx,
+		insertQuery,
+		ownerId,
+		attachment.ID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.UploadedBy,
+		attachment.CreatedAt)
+	return err
+}
+
+// delete удаляет вложение владельца. Если вложение не найдено, возвращается pgx.ErrNoRows.
+func (t attachmentTable) delete(ctx context.Context, conn db.DBTX, ownerId, attachmentId string) error {
+	tag, err := conn.Exec(ctx, `DELETE FROM `+t.name+` WHERE `+t.ownerColumn+` = $1 AND id = $2`, ownerId, attachmentId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// replace заменяет вложения владельца набором из снимка версии.
+func (t attachmentTable) replace(ctx context.Context, conn db.DBTX, ownerId string, attachments []models.Attachment) error {
+	if _, err := conn.Exec(ctx, `DELETE FROM `+t.name+` WHERE `+t.ownerColumn+` = $1`, ownerId); err != nil {
+		return err
+	}
+	for i := range attachments {
+		if err := t.insert(ctx, conn, ownerId, &attachments[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTenderAttachments возвращает вложения тендера в порядке загрузки.
+func (r *PostgresTenderRepository) GetTenderAttachments(ctx context.Context, tenderId string) ([]models.Attachment, error) {
+	return tenderAttachments.list(ctx, r.conn(ctx), tenderId)
+}
+
+// GetTenderAttachment возвращает вложение тендера.
+func (r *PostgresTenderRepository) GetTenderAttachment(ctx context.Context, tenderId, attachmentId string) (*models.Attachment, error) {
+	return tenderAttachments.get(ctx, r.conn(ctx), tenderId, attachmentId)
+}
+
+// CreateTenderAttachment добавляет вложение в тендер и создаёт новую версию тендера.
+// Тендер блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) CreateTenderAttachment(ctx context.Context, tenderId string, expectedVersion int, attachment models.Attachment) (*models.Tender, error) {
+	if err := r.beginTenderChange(ctx, tenderId, expectedVersion); err != nil {
+		return nil, err
+	}
+	if err := tenderAttachments.insert(ctx, r.conn(ctx), tenderId, &attachment); err != nil {
+		return nil, err
+	}
+	return r.commitTenderChange(ctx, tenderId)
+}
+
+// DeleteTenderAttachment удаляет вложение тендера и создаёт новую версию тендера.
+// Тендер блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) DeleteTenderAttachment(ctx context.Context, tenderId, attachmentId string, expectedVersion int) (*models.Tender, error) {
+	if err := r.beginTenderChange(ctx, tenderId, expectedVersion); err != nil {
+		return nil, err
+	}
+	if err := tenderAttachments.delete(ctx, r.conn(ctx), tenderId, attachmentId); err != nil {
+		return nil, err
+	}
+	return r.commitTenderChange(ctx, tenderId)
+}
+
+// GetBidAttachments возвращает вложения предложения в порядке загрузки.
+func (r *PostgresBidRepository) GetBidAttachments(ctx context.Context, bidId string) ([]models.Attachment, error) {
+	return bidAttachments.list(ctx, r.conn(ctx), bidId)
+}
+
+// GetBidAttachment возвращает вложение предложения.
+func (r *PostgresBidRepository) GetBidAttachment(ctx context.Context, bidId, attachmentId string) (*models.Attachment, error) {
+	return bidAttachments.get(ctx, r.conn(ctx), bidId, attachmentId)
+}
+
+// CreateBidAttachment добавляет вложение в предложение и создаёт новую версию предложения.
+// Предложение блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresBidRepository) CreateBidAttachment(ctx context.Context, bidId string, expectedVersion int, attachment models.Attachment) (*models.Bid, error) {
+	if err := r.beginBidChange(ctx, bidId, expectedVersion); err != nil {
+		return nil, err
+	}
+	if err := bidAttachments.insert(ctx, r.conn(ctx), bidId, &attachment); err != nil {
+		return nil, err
+	}
+	return r.commitBidChange(ctx, bidId)
+}
+
+// DeleteBidAttachment удаляет вложение предложения и создаёт новую версию предложения.
+// Предложение блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresBidRepository) DeleteBidAttachment(ctx context.Context, bidId, attachmentId string, expectedVersion int) (*models.Bid, error) {
+	if err := r.beginBidChange(ctx, bidId, expectedVersion); err != nil {
+		return nil, err
+	}
+	if err := bidAttachments.delete(ctx, r.conn(ctx), bidId, attachmentId); err != nil {
+		return nil, err
+	}
+	return r.commitBidChange(ctx, bidId)
+}
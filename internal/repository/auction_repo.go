@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// auctionRoundColumns - список столбцов таблицы auction_round в порядке, ожидаемом scanAuctionRound.
+const auctionRoundColumns = `number, starts_at, ends_at, closed_at`
+
+// bidPricedAt - время, когда предложение получило текущую цену: последнее снижение в аукционе
+// или создание предложения.
+const bidPricedAt = `COALESCE((SELECT MAX(created_at) FROM auction_rebid WHERE bid_id = bid.id), bid.created_at)`
+
+// AuctionRepository - интерфейс для работы с аукционами по тендерам.
+type AuctionRepository interface {
+	GetAuction(ctx context.Context, tenderId string) (*models.Auction, error)
+	SaveAuction(ctx context.Context, tenderId string, auctionReq models.AuctionRequest, now time.Time) (*models.Auction, error)
+	LockActiveRound(ctx context.Context, tenderId string, now time.Time) (*models.AuctionRound, error)
+	SaveRebid(ctx context.Context, tenderId string, rebid models.AuctionRebid) error
+	GetLeaderboard(ctx context.Context, tenderId, currency, viewerId string) ([]models.LeaderboardEntry, error)
+	CloseEndedRounds(ctx context.Context, now time.Time, limit int) ([]string, error)
+	FinishAuction(ctx context.Context, tenderId string, now time.Time) (*models.Auction, error)
+}
+
+// PostgresAuctionRepository - реализация AuctionRepository для базы данных.
+type PostgresAuctionRepository struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresAuctionRepository создаёт новый экземпляр PostgresAuctionRepository.
+func NewPostgresAuctionRepository(db *pgxpool.Pool) *PostgresAuctionRepository {
+	return &PostgresAuctionRepository{DB: db}
+}
+
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresAuctionRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// scanAuctionRound считывает раунд аукциона из строки результата, выбранной по auctionRoundColumns.
+func scanAuctionRound(row pgx.Row) (*models.AuctionRound, error) {
+	var round models.AuctionRound
+	err := row.Scan(&round.Number, &round.StartsAt, &round.EndsAt, &round.ClosedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &round, nil
+}
+
+// GetAuction возвращает аукцион по тендеру вместе с раундами по порядку.
+// Если аукцион по тендеру не настроен, возвращается pgx.ErrNoRows.
+func (r *PostgresAuctionRepository) GetAuction(ctx context.Context, tenderId string) (*models.Auction, error) {
+	auction := models.Auction{TenderID: tenderId}
+	query := `SELECT currency, winner_bid_id, finished_at, created_at FROM tender_auction WHERE tender_id = $1`
+	err := r.conn(ctx).QueryRow(ctx, query, tenderId).Scan(&auction.Currency, &auction.WinnerBidID, &auction.FinishedAt, &auction.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	roundsQuery := `SELECT ` + auctionRoundColumns + ` FROM auction_round WHERE tender_id = $1 ORDER BY number`
+	rows, err := r.conn(ctx).Query(ctx, roundsQuery, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	auction.Rounds = []models.AuctionRound{}
+	for rows.Next() {
+		round, err := scanAuctionRound(rows)
+		if err != nil {
+			return nil, err
+		}
+		auction.Rounds = append(auction.Rounds, *round)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &auction, nil
+}
+
+// SaveAuction создаёт аукцион по тендеру или заменяет его валюту и расписание раундов.
+// Расписание нельзя изменить после начала первого раунда. Настройки аукциона блокируются
+// до конца транзакции, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresAuctionRepository) SaveAuction(ctx context.Context, tenderId string, auctionReq models.AuctionRequest, now time.Time) (*models.Auction, error) {
+	var started bool
+	lockQuery := `
+		SELECT EXISTS(SELECT 1 FROM auction_round WHERE tender_id = a.tender_id AND starts_at <= $2)
+		FROM tender_auction a WHERE a.tender_id = $1 FOR UPDATE`
+	err := r.conn(ctx).QueryRow(ctx, lockQuery, tenderId, now).Scan(&started)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if started {
+		return nil, models.NewErrorResponse(http.StatusConflict, "auction has already started and cannot be changed")
+	}
+
+	upsertQuery := `
+		INSERT INTO tender_auction (tender_id, currency, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (tender_id) DO UPDATE SET currency = EXCLUDED.currency`
+	if _, err = r.conn(ctx).Exec(ctx, upsertQuery, tenderId, auctionReq.Currency, now); err != nil {
+		return nil, err
+	}
+
+	if _, err = r.conn(ctx).Exec(ctx, `DELETE FROM auction_round WHERE tender_id = $1`, tenderId); err != nil {
+		return nil, err
+	}
+	insertRoundQuery := `INSERT INTO auction_round (tender_id, number, starts_at, ends_at) VALUES ($1, $2, $3, $4)`
+	for i, round := range auctionReq.Rounds {
+		_, err = r.conn(ctx).Exec(ctx, insertRoundQuery, tenderId, i+1, round.StartsAt.UTC(), round.EndsAt.UTC())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.GetAuction(ctx, tenderId)
+}
+
+// LockActiveRound возвращает раунд аукциона, идущий в момент now, и блокирует его от закрытия
+// до конца транзакции. Если ни один раунд не идёт, возвращается pgx.ErrNoRows.
+func (r *PostgresAuctionRepository) LockActiveRound(ctx context.Context, tenderId string, now time.Time) (*models.AuctionRound, error) {
+	query := `
+		SELECT ` + auctionRoundColumns + ` FROM auction_round
+		WHERE tender_id = $1 AND closed_at IS NULL AND starts_at <= $2 AND ends_at > $2
+		FOR SHARE`
+	return scanAuctionRound(r.conn(ctx).QueryRow(ctx, query, tenderId, now))
+}
+
+// SaveRebid сохраняет снижение цены предложения в раунде аукциона.
+func (r *PostgresAuctionRepository) SaveRebid(ctx context.Context, tenderId string, rebid models.AuctionRebid) error {
+	query := `
+		INSERT INTO auction_rebid (bid_id, tender_id, round_number, previous_price, price_amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.conn(ctx).Exec(ctx, query, rebid.BidID, tenderId, rebid.RoundNumber, rebid.PreviousPrice, rebid.PriceAmount, rebid.CreatedAt)
+	return err
+}
+
+// GetLeaderboard возвращает опубликованные предложения с ценой в валюте аукциона по возрастанию цены;
+// при равной цене выше то предложение, которое получило её раньше. Идентификатор и название раскрываются
+// только для предложений, видимых пользователю viewerId. Места в таблице не заполняются.
+func (r *PostgresAuctionRepository) GetLeaderboard(ctx context.Context, tenderId, currency, viewerId string) ([]models.LeaderboardEntry, error) {
+	query := `
+		SELECT bid.id, bid.name, bid.price_amount, ` + bidPricedAt + `,
+		       bid.author_id = $2, ` + bidVisibilityPredicate + `
+		FROM bid
+		WHERE bid.tender_id = $1 AND bid.status = $3 AND bid.currency = $4 AND bid.price_amount IS NOT NULL
+		ORDER BY bid.price_amount, 4, bid.id`
+	rows, err := r.conn(ctx).Query(ctx, query, tenderId, viewerId, models.PublishedBid, currency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.LeaderboardEntry{}
+	for rows.Next() {
+		var entry models.LeaderboardEntry
+		var visible bool
+		if err = rows.Scan(&entry.BidID, &entry.BidName, &entry.PriceAmount, &entry.PricedAt, &entry.Own, &visible); err != nil {
+			return nil, err
+		}
+		if !visible {
+			entry.BidID, entry.BidName = "", ""
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// CloseEndedRounds закрывает не более limit раундов, окончившихся к моменту now, и возвращает
+// идентификаторы их тендеров. Раунды, заблокированные идущими снижениями цены, пропускаются
+// до следующего запуска, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresAuctionRepository) CloseEndedRounds(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	query := `
+		UPDATE auction_round SET closed_at = $1
+		WHERE (tender_id, number) IN (
+			SELECT tender_id, number FROM auction_round
+			WHERE closed_at IS NULL AND ends_at <= $1
+			ORDER BY ends_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING tender_id`
+	rows, err := r.conn(ctx).Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenderIds []string
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var tenderId string
+		if err = rows.Scan(&tenderId); err != nil {
+			return nil, err
+		}
+		if !seen[tenderId] {
+			seen[tenderId] = true
+			tenderIds = append(tenderIds, tenderId)
+		}
+	}
+	return tenderIds, rows.Err()
+}
+
+// FinishAuction завершает аукцион, все раунды которого закрыты: победителем становится опубликованное
+// предложение с наименьшей ценой в валюте аукциона, при равной цене - получившее её раньше.
+// Если раунды ещё идут или аукцион уже завершён, возвращается pgx.ErrNoRows.
+func (r *PostgresAuctionRepository) FinishAuction(ctx context.Context, tenderId string, now time.Time) (*models.Auction, error) {
+	query := `
+		UPDATE tender_auction a SET finished_at = $2, winner_bid_id = (
+			SELECT bid.id FROM bid
+			WHERE bid.tender_id = a.tender_id AND bid.status = $3 AND bid.currency = a.currency
+			  AND bid.price_amount IS NOT NULL
+			ORDER BY bid.price_amount, ` + bidPricedAt + `, bid.id
+			LIMIT 1
+		)
+		WHERE a.tender_id = $1 AND a.finished_at IS NULL
+		  AND NOT EXISTS(SELECT 1 FROM auction_round WHERE tender_id = a.tender_id AND closed_at IS NULL)
+		RETURNING a.tender_id`
+	var finishedId string
+	if err := r.conn(ctx).QueryRow(ctx, query, tenderId, now, models.PublishedBid).Scan(&finishedId); err != nil {
+		return nil, err
+	}
+	return r.GetAuction(ctx, finishedId)
+}
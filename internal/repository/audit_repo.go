@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditRepository - интерфейс для работы с журналом аудита.
+type AuditRepository interface {
+	SaveEvent(ctx context.Context, event models.AuditEvent, tenderId string) error
+	GetEvents(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, error)
+}
+
+// PostgresAuditRepository - реализация AuditRepository для базы данных.
+type PostgresAuditRepository struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresAuditRepository создаёт новый экземпляр PostgresAuditRepository.
+func NewPostgresAuditRepository(db *pgxpool.Pool) *PostgresAuditRepository {
+	return &PostgresAuditRepository{DB: db}
+}
+
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresAuditRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// SaveEvent сохраняет запись журнала аудита. Организация события определяется по тендеру tenderId,
+// к которому относится объект. Вызванный внутри транзакции, метод фиксирует событие вместе с изменением.
+func (r *PostgresAuditRepository) SaveEvent(ctx context.Context, event models.AuditEvent, tenderId string) error {
+	insertQuery := `INSERT INTO audit_event (id, actor, entity_type, entity_id, organization_id, action, before, after, created_at)
+	                VALUES ($1, $2, $3, $4, (SELECT organization_id FROM tender WHERE id = $5), $6, $7, $8, $9)`
+	_, err := r.conn(ctx).Exec(
+		ctx,
+		insertQuery,
+		event.ID,
+		event.Actor,
+		event.EntityType,
+		event.EntityID,
+		tenderId,
+		event.Action,
+		nullableJSON(event.Before),
+		nullableJSON(event.After),
+		event.CreatedAt)
+	return err
+}
+
+// auditBidVisibility - условие видимости записи журнала пользователю $2: события предложений (тип $1)
+// видны, только пока само предложение видно пользователю по bidVisibilityPredicate. Иначе ответственные
+// за организацию тендера прочитали бы в снимках условия неопубликованных и ещё не вскрытых предложений.
+const auditBidVisibility = `(
+		entity_type <> $1
+		OR EXISTS (SELECT 1 FROM bid WHERE bid.id = audit_event.entity_id AND ` + bidVisibilityPredicate + `)
+	)`
+
+// GetEvents возвращает записи журнала аудита по фильтру, начиная с самых новых.
+func (r *PostgresAuditRepository) GetEvents(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, error) {
+	query := `SELECT id, actor, entity_type, entity_id, COALESCE(organization_id::text, ''), action,
+	                 COALESCE(before, 'null'::jsonb), COALESCE(after, 'null'::jsonb), created_at
+	          FROM audit_event`
+	filters := []string{
+		"organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = $2)",
+		auditBidVisibility,
+	}
+	args := []interface{}{models.BidEntity, filter.ViewerID, models.PublishedBid}
+	argIndex := 4
+
+	if filter.EntityID != "" {
+		filters = append(filters, fmt.Sprintf("entity_id = $%d", argIndex))
+		args = append(args, filter.EntityID)
+		argIndex++
+	}
+	if filter.Actor != "" {
+		filters = append(filters, fmt.Sprintf("actor = $%d", argIndex))
+		args = append(args, filter.Actor)
+		argIndex++
+	}
+	if filter.From != nil {
+		filters = append(filters, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, *filter.From)
+		argIndex++
+	}
+	if filter.To != nil {
+		filters = append(filters, fmt.Sprintf("created_at <= $%d", argIndex))
+		args = append(args, *filter.To)
+		argIndex++
+	}
+
+	query += " WHERE " + strings.Join(filters, " AND ")
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.AuditEvent
+	for rows.Next() {
+		var event models.AuditEvent
+		var before, after []byte
+		err = rows.Scan(
+			&event.ID,
+			&event.Actor,
+			&event.EntityType,
+			&event.EntityID,
+			&event.OrganizationID,
+			&event.Action,
+			&before,
+			&after,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		event.Before, event.After = before, after
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// nullableJSON возвращает nil для пустого JSON, чтобы в базе сохранился NULL.
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return string(data)
+}
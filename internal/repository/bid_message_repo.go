@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// bidMessageColumns - список столбцов сообщения m с автором e в порядке, ожидаемом scanBidMessage.
+const bidMessageColumns = `m.id, m.bid_id, e.username, m.author_side, m.bid_version, m.body, m.created_at`
+
+// bidNegotiationPredicate - условие участия пользователя $1 в переписке по предложению: автор, ответственные
+// организации автора и ответственные за организацию тендера в любом статусе предложения, если тендер
+// не находится в закрытом режиме до вскрытия предложений.
+const bidNegotiationPredicate = `(
+		bid.author_id = $1
+		OR (bid.author_type = 'Organization' AND EXISTS (
+			SELECT 1 FROM organization_responsible a
+			JOIN organization_responsible v ON a.organization_id = v.organization_id
+			WHERE a.user_id = bid.author_id AND v.user_id = $1))
+		OR EXISTS (
+			SELECT 1 FROM tender t
+			JOIN organization_responsible o ON o.organization_id = t.organization_id
+			WHERE t.id = bid.tender_id AND o.user_id = $1 AND NOT ` + authz.SealedCondition + `)
+	)`
+
+// bidMessageOrder - порядок переписки: от ранних сообщений к поздним, при равном времени - по идентификатору.
+var bidMessageOrder = keysetOrder[models.BidMessage]{
+	{column: "m.created_at", cast: "timestamp", value: func(m *models.BidMessage) any { return keysetTime(m.CreatedAt) }},
+	{column: "m.id", cast: "uuid", value: func(m *models.BidMessage) any { return m.ID }},
+}
+
+// scanBidMessage считывает сообщение из строки результата, выбранной по bidMessageColumns.
+func scanBidMessage(row pgx.Row) (*models.BidMessage, error) {
+	var message models.BidMessage
+	err := row.Scan(
+		&message.ID,
+		&message.BidID,
+		&message.AuthorUsername,
+		&message.AuthorSide,
+		&message.BidVersion,
+		&message.Body,
+		&message.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// GetBidMessages возвращает страницу переписки по предложению.
+func (r *PostgresBidRepository) GetBidMessages(ctx context.Context, bidId string, page models.Page) (models.Paged[models.BidMessage], error) {
+	tail, args, err := bidMessageOrder.page([]string{"m.bid_id = $1"}, []interface{}{bidId}, page)
+	if err != nil {
+		return models.Paged[models.BidMessage]{}, err
+	}
+	query := `SELECT ` + bidMessageColumns + ` FROM bid_message m JOIN employee e ON e.id = m.author_id` + tail
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return models.Paged[models.BidMessage]{}, err
+	}
+	defer rows.Close()
+
+	var messages []models.BidMessage
+	for rows.Next() {
+		message, err := scanBidMessage(rows)
+		if err != nil {
+			return models.Paged[models.BidMessage]{}, err
+		}
+		messages = append(messages, *message)
+	}
+	if err = rows.Err(); err != nil {
+		return models.Paged[models.BidMessage]{}, err
+	}
+	return bidMessageOrder.collect(messages, page.Limit), nil
+}
+
+// CreateBidMessage сохраняет сообщение пользователя authorId в переписке по предложению.
+func (r *PostgresBidRepository) CreateBidMessage(ctx context.Context, authorId string, message models.BidMessage) (*models.BidMessage, error) {
+	query := `
+		WITH m AS (
+			INSERT INTO bid_message (id, bid_id, author_id, author_side, bid_version, body, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING *
+		)
+		SELECT ` + bidMessageColumns + ` FROM m JOIN employee e ON e.id = m.author_id`
+	return scanBidMessage(r.conn(ctx).QueryRow(ctx, query, uuid.New().String(), message.BidID, authorId,
+		message.AuthorSide, message.BidVersion, message.Body, time.Now().UTC()))
+}
+
+// MarkBidMessagesRead отмечает сообщения по предложению, отправленные не позднее readAt, прочитанными
+// пользователем userId. Отметка только продвигается вперёд: более ранний readAt её не меняет.
+func (r *PostgresBidRepository) MarkBidMessagesRead(ctx context.Context, bidId, userId string, readAt time.Time) error {
+	query := `
+		INSERT INTO bid_message_read (bid_id, user_id, last_read_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (bid_id, user_id) DO UPDATE
+		SET last_read_at = GREATEST(bid_message_read.last_read_at, EXCLUDED.last_read_at)`
+	_, err := r.conn(ctx).Exec(ctx, query, bidId, userId, readAt)
+	return err
+}
+
+// GetUnreadBidMessages возвращает число непрочитанных пользователем userId сообщений по каждому предложению,
+// в переписке по которому он участвует и есть такие сообщения. Собственные сообщения пользователя не учитываются.
+func (r *PostgresBidRepository) GetUnreadBidMessages(ctx context.Context, userId string) ([]models.BidUnreadMessages, error) {
+	query := `
+		SELECT bid.id, bid.tender_id, COUNT(*)
+		FROM bid_message m
+		JOIN bid ON bid.id = m.bid_id
+		LEFT JOIN bid_message_read r ON r.bid_id = m.bid_id AND r.user_id = $1
+		WHERE m.author_id <> $1
+		  AND (r.last_read_at IS NULL OR m.created_at > r.last_read_at)
+		  AND ` + bidNegotiationPredicate + `
+		GROUP BY bid.id, bid.tender_id
+		ORDER BY MAX(m.created_at) DESC, bid.id`
+	rows, err := r.conn(ctx).Query(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unread := []models.BidUnreadMessages{}
+	for rows.Next() {
+		var item models.BidUnreadMessages
+		if err = rows.Scan(&item.BidID, &item.TenderID, &item.Unread); err != nil {
+			return nil, err
+		}
+		unread = append(unread, item)
+	}
+	return unread, rows.Err()
+}
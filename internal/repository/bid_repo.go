@@ -2,29 +2,57 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/db"
 	"github.com/senyabanana/tender-service/internal/models"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lib/pq"
 )
 
+// uniqueViolationCode - код ошибки PostgreSQL при нарушении ограничения уникальности.
+const uniqueViolationCode = "23505"
+
+// bidColumns - список столбцов таблицы bid в порядке, ожидаемом scanBid.
+const bidColumns = `id, name, description, status, tender_id, author_type, author_id, version, created_at,
+	price_amount, currency, timeframe_days, warranty_terms, lot_prices`
+
 // BidRepository - интерфейс для работы с предложениями.
 type BidRepository interface {
 	CreateBid(ctx context.Context, bidReq models.BidRequest) (*models.Bid, error)
-	GetUserBid(ctx context.Context, limit, offset int, username string) ([]models.Bid, error)
-	GetTenderBid(ctx context.Context, tenderId string, limit, offset int) ([]models.Bid, error)
+	GetUserBid(ctx context.Context, page models.Page, username string) (models.Paged[models.Bid], error)
+	GetTenderBid(ctx context.Context, tenderId, viewerId string, filter models.BidFilter, page models.Page) (models.Paged[models.Bid], error)
+	CountTenderBids(ctx context.Context, tenderId string, status models.BidStatus) (int, error)
+	LowerBidPrice(ctx context.Context, bidId, currency string, priceAmount float64, expectedVersion int) (*models.Bid, error)
 	GetBidStatus(ctx context.Context, bidId string) (*models.BidStatus, error)
-	UpdateBidStatus(ctx context.Context, bidId, status string) (*models.Bid, error)
-	EditBid(ctx context.Context, bidId string, updateFields map[string]interface{}) (*models.Bid, error)
-	SubmitBidDecision(ctx context.Context, bidId, decision string) (*models.Bid, error)
+	UpdateBidStatus(ctx context.Context, bidId, status string, expectedVersion int) (*models.Bid, error)
+	EditBid(ctx context.Context, bidId string, expectedVersion int, updateFields map[string]interface{}) (*models.Bid, error)
+	SubmitBidDecision(ctx context.Context, bidId, userId, decision string) (*models.BidDecisionResult, error)
 	SubmitBidFeedback(ctx context.Context, review models.BidReview, bidId string) (*models.Bid, error)
-	RollbackBid(ctx context.Context, bidId string, version int) (*models.Bid, error)
-	GetBidReviews(ctx context.Context, tenderId, authorUsername, requesterUsername string, limit, offset int) ([]models.BidReview, error)
+	RollbackBid(ctx context.Context, bidId string, version, expectedVersion int) (*models.Bid, error)
+	GetBidReviews(ctx context.Context, tenderId, authorUsername, requesterUsername string, page models.Page) (models.Paged[models.BidReview], error)
+	GetBidVersions(ctx context.Context, bidId string) ([]models.Bid, error)
+	GetBidVersion(ctx context.Context, bidId string, version int) (*models.Bid, error)
+	GetBidAttachments(ctx context.Context, bidId string) ([]models.Attachment, error)
+	GetBidAttachment(ctx context.Context, bidId, attachmentId string) (*models.Attachment, error)
+	CreateBidAttachment(ctx context.Context, bidId string, expectedVersion int, attachment models.Attachment) (*models.Bid, error)
+	DeleteBidAttachment(ctx context.Context, bidId, attachmentId string, expectedVersion int) (*models.Bid, error)
+	GetBidMessages(ctx context.Context, bidId string, page models.Page) (models.Paged[models.BidMessage], error)
+	CreateBidMessage(ctx context.Context, authorId string, message models.BidMessage) (*models.BidMessage, error)
+	MarkBidMessagesRead(ctx context.Context, bidId, userId string, readAt time.Time) error
+	GetUnreadBidMessages(ctx context.Context, userId string) ([]models.BidUnreadMessages, error)
 }
 
 // PostgresBidRepository - реализация BidRepository для базы данных.
@@ -37,6 +65,114 @@ func NewPostgresBidRepository(db *pgxpool.Pool) *PostgresBidRepository {
 	return &PostgresBidRepository{DB: db}
 }
 
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresBidRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// bidScanTargets возвращает поля предложения в порядке bidColumns.
+func bidScanTargets(bid *models.Bid) []interface{} {
+	return []interface{}{
+		&bid.ID,
+		&bid.Name,
+		&bid.Description,
+		&bid.Status,
+		&bid.TenderId,
+		&bid.AuthorType,
+		&bid.AuthorId,
+		&bid.Version,
+		&bid.CreatedAt,
+		&bid.PriceAmount,
+		&bid.Currency,
+		&bid.TimeframeDays,
+		&bid.WarrantyTerms,
+		&bid.LotPrices,
+	}
+}
+
+// scanBid считывает предложение из строки результата, выбранной по bidColumns.
+func scanBid(row pgx.Row) (*models.Bid, error) {
+	var bid models.Bid
+	if err := row.Scan(bidScanTargets(&bid)...); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// scanBidVersion считывает снимок версии предложения, выбранный по bidColumns и столбцу attachments.
+func scanBidVersion(row pgx.Row) (*models.Bid, error) {
+	var bid models.Bid
+	if err := row.Scan(append(bidScanTargets(&bid), &bid.Attachments)...); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// collectBids считывает все предложения из результата запроса.
+func collectBids(rows pgx.Rows) ([]models.Bid, error) {
+	defer rows.Close()
+
+	var bids []models.Bid
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, *bid)
+	}
+	return bids, rows.Err()
+}
+
+// bidHistoryColumns - столбцы снимка предложения из bid_history в порядке bidColumns.
+// Тендер предложения не меняется между версиями, поэтому берётся из текущей записи.
+const bidHistoryColumns = `h.bid_id, h.name, h.description, h.status, b.tender_id, h.author_type, h.author_id, h.version, h.created_at,
+	h.price_amount, h.currency, h.timeframe_days, h.warranty_terms, h.lot_prices`
+
+// bidVisibilityPredicate - условие видимости предложения пользователю $2: автору, ответственным
+// организации автора и, для предложений в статусе $3 (Published), ответственным за организацию тендера,
+// если тендер не находится в закрытом режиме до вскрытия предложений.
+const bidVisibilityPredicate = `(
+		bid.author_id = $2
+		OR (bid.author_type = 'Organization' AND EXISTS (
+			SELECT 1 FROM organization_responsible a
+			JOIN organization_responsible v ON a.organization_id = v.organization_id
+			WHERE a.user_id = bid.author_id AND v.user_id = $2))
+		OR (bid.status = $3 AND EXISTS (
+			SELECT 1 FROM tender t
+			JOIN organization_responsible o ON o.organization_id = t.organization_id
+			WHERE t.id = bid.tender_id AND o.user_id = $2 AND NOT ` + authz.SealedCondition + `))
+	)`
+
+// lockBid выбирает предложение с блокировкой строки до конца текущей транзакции.
+func (r *PostgresBidRepository) lockBid(ctx context.Context, bidId string) (*models.Bid, error) {
+	query := `SELECT ` + bidColumns + ` FROM bid WHERE id = $1 FOR UPDATE`
+	return scanBid(r.conn(ctx).QueryRow(ctx, query, bidId))
+}
+
+// saveBidHistory сохраняет снимок текущей версии предложения вместе с его вложениями в bid_history.
+func (r *PostgresBidRepository) saveBidHistory(ctx context.Context, bid *models.Bid) error {
+	historyInsertQuery := `INSERT INTO bid_history (bid_id, name, description, status, author_type, author_id, version, created_at,
+                                                  price_amount, currency, timeframe_days, warranty_terms, lot_prices, attachments)
+                          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, ` + bidAttachments.snapshot("$1") + `)`
+	_, err := r.conn(ctx).Exec(
+		ctx,
+		historyInsertQuery,
+		bid.ID,
+		bid.Name,
+		bid.Description,
+		bid.Status,
+		bid.AuthorType,
+		bid.AuthorId,
+		bid.Version,
+		bid.CreatedAt,
+		bid.PriceAmount,
+		bid.Currency,
+		bid.TimeframeDays,
+		bid.WarrantyTerms,
+		bid.LotPrices)
+	return err
+}
+
 // CreateBid создает новое предложение.
 func (r *PostgresBidRepository) CreateBid(ctx context.Context, bidReq models.BidRequest) (*models.Bid, error) {
 	newBid := models.Bid{
@@ -49,10 +185,15 @@ func (r *PostgresBidRepository) CreateBid(ctx context.Context, bidReq models.Bid
 		AuthorId:    bidReq.AuthorId,
 		Version:     1,
 		CreatedAt:   time.Now().UTC(),
+		BidTerms:    bidReq.BidTerms,
+	}
+	if err := r.checkBidLots(ctx, newBid.TenderId, newBid.LotPrices); err != nil {
+		return nil, err
 	}
-	insertQuery := `INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, version, created_at)
-                   VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	_, err := r.DB.Exec(
+
+	insertQuery := `INSERT INTO bid (` + bidColumns + `)
+                   VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	_, err := r.conn(ctx).Exec(
 		ctx,
 		insertQuery,
 		newBid.ID,
@@ -63,183 +204,211 @@ func (r *PostgresBidRepository) CreateBid(ctx context.Context, bidReq models.Bid
 		newBid.AuthorType,
 		newBid.AuthorId,
 		newBid.Version,
-		newBid.CreatedAt)
+		newBid.CreatedAt,
+		newBid.PriceAmount,
+		newBid.Currency,
+		newBid.TimeframeDays,
+		newBid.WarrantyTerms,
+		newBid.LotPrices)
 	if err != nil {
 		return nil, err
 	}
 	return &newBid, nil
 }
 
-// GetUserBid возвращает список предложений пользователя.
-func (r *PostgresBidRepository) GetUserBid(ctx context.Context, limit, offset int, username string) ([]models.Bid, error) {
-	var query string
+// GetUserBid возвращает страницу списка предложений пользователя.
+func (r *PostgresBidRepository) GetUserBid(ctx context.Context, page models.Page, username string) (models.Paged[models.Bid], error) {
+	var filters []string
 	var args []interface{}
 	if username != "" {
-		query = `
-			SELECT bid.id, bid.name, bid.description, bid.status, bid.tender_id, bid.author_type, bid.author_id, bid.version, bid.created_at
-			FROM bid
-			JOIN employee e ON bid.author_id = e.id
-   			WHERE $1 = e.username
-			ORDER BY bid.name
-			LIMIT $2 OFFSET $3;`
-		args = append(args, username, limit, offset)
-	} else {
-		query = `
-			SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-			FROM bid
-			ORDER BY name
-			LIMIT $1 OFFSET $2`
-		args = append(args, limit, offset)
-	}
-
-	rows, err := r.DB.Query(ctx, query, args...)
-	if err != nil {
-		return nil, err
+		filters = append(filters, "author_id = (SELECT id FROM employee WHERE username = $1)")
+		args = append(args, username)
 	}
-	defer rows.Close()
 
-	var userBids []models.Bid
-	for rows.Next() {
-		var bid models.Bid
-		if err := rows.Scan(
-			&bid.ID,
-			&bid.Name,
-			&bid.Description,
-			&bid.Status,
-			&bid.TenderId,
-			&bid.AuthorType,
-			&bid.AuthorId,
-			&bid.Version,
-			&bid.CreatedAt); err != nil {
-			return nil, err
-		}
-		userBids = append(userBids, bid)
+	tail, args, err := bidNameOrder.page(filters, args, page)
+	if err != nil {
+		return models.Paged[models.Bid]{}, err
+	}
+	rows, err := r.conn(ctx).Query(ctx, `SELECT `+bidColumns+` FROM bid`+tail, args...)
+	if err != nil {
+		return models.Paged[models.Bid]{}, err
 	}
-	return userBids, nil
+	bids, err := collectBids(rows)
+	if err != nil {
+		return models.Paged[models.Bid]{}, err
+	}
+	return bidNameOrder.collect(bids, page.Limit), nil
 }
 
-// GetTenderBid возвращает список предложений для тендера.
-func (r *PostgresBidRepository) GetTenderBid(ctx context.Context, tenderId string, limit, offset int) ([]models.Bid, error) {
-	query := `
-		SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-		FROM bid
-		WHERE tender_id = $1
-		ORDER BY name
-		LIMIT $2 OFFSET $3`
-	rows, err := r.DB.Query(ctx, query, tenderId, limit, offset)
+// GetTenderBid возвращает страницу списка предложений для тендера, видимых пользователю viewerId.
+// Сторона автора видит свои предложения в любом статусе, ответственные за тендер - только опубликованные.
+func (r *PostgresBidRepository) GetTenderBid(ctx context.Context, tenderId, viewerId string, filter models.BidFilter, page models.Page) (models.Paged[models.Bid], error) {
+	order, ok := bidSortOrders[filter.Sort]
+	if !ok {
+		order = bidNameOrder
+	}
+	query, args, err := buildTenderBidsQuery(tenderId, viewerId, filter, order, page)
 	if err != nil {
-		return nil, err
+		return models.Paged[models.Bid]{}, err
 	}
-	defer rows.Close()
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return models.Paged[models.Bid]{}, err
+	}
+	bids, err := collectBids(rows)
+	if err != nil {
+		return models.Paged[models.Bid]{}, err
+	}
+	return order.collect(bids, page.Limit), nil
+}
 
-	var bids []models.Bid
-	for rows.Next() {
-		var bid models.Bid
-		if err := rows.Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId, &bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt); err != nil {
-			return nil, err
+// CountTenderBids возвращает число предложений по тендеру в указанном статусе.
+func (r *PostgresBidRepository) CountTenderBids(ctx context.Context, tenderId string, status models.BidStatus) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM bid WHERE tender_id = $1 AND status = $2`
+	err := r.conn(ctx).QueryRow(ctx, query, tenderId, status).Scan(&count)
+	return count, err
+}
+
+// Ключи сортировки списков предложений.
+var (
+	bidNameKey = keysetKey[models.Bid]{column: "name", cast: "text", value: func(b *models.Bid) any { return b.Name }}
+	bidIdKey   = keysetKey[models.Bid]{column: "id", cast: "uuid", value: func(b *models.Bid) any { return b.ID }}
+
+	bidCreatedKey = keysetKey[models.Bid]{column: "created_at", cast: "timestamp", value: func(b *models.Bid) any { return keysetTime(b.CreatedAt) }}
+	bidVersionKey = keysetKey[models.Bid]{column: "version", cast: "numeric", value: func(b *models.Bid) any { return b.Version }}
+	bidStatusKey  = keysetKey[models.Bid]{column: "status", cast: "text", value: func(b *models.Bid) any { return string(b.Status) }}
+
+	bidPriceKey = keysetKey[models.Bid]{column: "price_amount", cast: "numeric", nullsLast: true, value: func(b *models.Bid) any {
+		if b.PriceAmount == nil {
+			return nil
 		}
-		bids = append(bids, bid)
+		return *b.PriceAmount
+	}}
+	bidTimeframeKey = keysetKey[models.Bid]{column: "timeframe_days", cast: "numeric", nullsLast: true, value: func(b *models.Bid) any {
+		if b.TimeframeDays == nil {
+			return nil
+		}
+		return *b.TimeframeDays
+	}}
+)
+
+// bidNameOrder - порядок списков предложений по умолчанию: по названию, при равных названиях - по идентификатору.
+var bidNameOrder = keysetOrder[models.Bid]{bidNameKey, bidIdKey}
+
+// bidSortOrders сопоставляет допустимые значения сортировки списка предложений с порядком сортировки.
+// Предложения без указанной цены или срока выводятся в конце списка.
+var bidSortOrders = map[string]keysetOrder[models.Bid]{
+	"":                          bidNameOrder,
+	models.BidSortName:          bidNameOrder,
+	models.SortCreatedAt:        {bidCreatedKey, bidIdKey},
+	models.SortCreatedAtDesc:    {descending(bidCreatedKey), descending(bidIdKey)},
+	models.SortVersion:          {bidVersionKey, bidIdKey},
+	models.SortStatus:           {bidStatusKey, bidNameKey, bidIdKey},
+	models.BidSortPrice:         {bidPriceKey, bidNameKey, bidIdKey},
+	models.BidSortPriceDesc:     {descending(bidPriceKey), bidNameKey, bidIdKey},
+	models.BidSortTimeframe:     {bidTimeframeKey, bidNameKey, bidIdKey},
+	models.BidSortTimeframeDesc: {descending(bidTimeframeKey), bidNameKey, bidIdKey},
+}
+
+// buildTenderBidsQuery формирует запрос страницы списка предложений по тендеру с учётом видимости,
+// фильтров и порядка сортировки.
+func buildTenderBidsQuery(tenderId, viewerId string, filter models.BidFilter, order keysetOrder[models.Bid], page models.Page) (string, []interface{}, error) {
+	filters := []string{"tender_id = $1", bidVisibilityPredicate}
+	args := []interface{}{tenderId, viewerId, models.PublishedBid}
+	argIndex := 4
+
+	if filter.Currency != "" {
+		filters = append(filters, fmt.Sprintf("currency = $%d", argIndex))
+		args = append(args, filter.Currency)
+		argIndex++
+	}
+
+	if filter.MinPrice != nil {
+		filters = append(filters, fmt.Sprintf("price_amount >= $%d", argIndex))
+		args = append(args, *filter.MinPrice)
+		argIndex++
+	}
+
+	if filter.MaxPrice != nil {
+		filters = append(filters, fmt.Sprintf("price_amount <= $%d", argIndex))
+		args = append(args, *filter.MaxPrice)
+		argIndex++
 	}
-	return bids, nil
+
+	if filter.MaxTimeframeDays != nil {
+		filters = append(filters, fmt.Sprintf("timeframe_days <= $%d", argIndex))
+		args = append(args, *filter.MaxTimeframeDays)
+	}
+
+	if len(filter.AuthorTypes) > 0 {
+		args = append(args, pq.Array(filter.AuthorTypes))
+		filters = append(filters, fmt.Sprintf("author_type = ANY($%d)", len(args)))
+	}
+	filters, args = appendListFilter(filters, args, filter.ListFilter, bidOrganizationCondition)
+
+	tail, args, err := order.page(filters, args, page)
+	if err != nil {
+		return "", nil, err
+	}
+	return `SELECT ` + bidColumns + ` FROM bid` + tail, args, nil
 }
 
 // GetBidStatus возвращает статус предложения.
 func (r *PostgresBidRepository) GetBidStatus(ctx context.Context, bidId string) (*models.BidStatus, error) {
 	var status models.BidStatus
 	query := `SELECT status FROM bid WHERE id = $1`
-	err := r.DB.QueryRow(ctx, query, bidId).Scan(&status)
+	err := r.conn(ctx).QueryRow(ctx, query, bidId).Scan(&status)
 	if err != nil {
 		return nil, err
 	}
 	return &status, nil
 }
 
-// UpdateBidStatus меняет статус предложения.
-func (r *PostgresBidRepository) UpdateBidStatus(ctx context.Context, bidId, status string) (*models.Bid, error) {
-	updateQuery := `UPDATE bid SET status = $1 WHERE id = $2`
-	_, err := r.DB.Exec(ctx, updateQuery, status, bidId)
+// bidStatusTransitions - допустимые переходы между статусами предложения. Статусы Approved и Rejected
+// устанавливаются только решениями по предложению.
+var bidStatusTransitions = map[models.BidStatus][]models.BidStatus{
+	models.CreatedBid:   {models.PublishedBid, models.CanceledBid},
+	models.PublishedBid: {models.CanceledBid},
+	models.CanceledBid:  {},
+}
+
+// UpdateBidStatus меняет статус предложения. Смена статуса создаёт новую версию предложения:
+// текущая версия блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+// Ожидаемая версия, если она передана, и допустимость перехода проверяются под блокировкой строки,
+// чтобы параллельные смены статуса не обошли друг друга.
+func (r *PostgresBidRepository) UpdateBidStatus(ctx context.Context, bidId, status string, expectedVersion int) (*models.Bid, error) {
+	currentBid, err := r.lockBid(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
-	var bid models.Bid
-	bidQuery := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-	             FROM bid WHERE id = $1`
-	err = r.DB.QueryRow(ctx, bidQuery, bidId).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderId,
-		&bid.AuthorType,
-		&bid.AuthorId,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
-	if err != nil {
+	if err = checkExpectedVersion(expectedVersion, currentBid.Version); err != nil {
 		return nil, err
 	}
-	return &bid, nil
+	if !slices.Contains(bidStatusTransitions[currentBid.Status], models.BidStatus(status)) {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid bid status")
+	}
+	return r.setBidStatus(ctx, currentBid, models.BidStatus(status))
 }
 
-// EditBid меняет описание предложения.
-func (r *PostgresBidRepository) EditBid(ctx context.Context, bidId string, updateFields map[string]interface{}) (*models.Bid, error) {
-	var currentBid models.Bid
-	selectQuery := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-	                FROM bid WHERE id = $1`
-	err := r.DB.QueryRow(ctx, selectQuery, bidId).Scan(
-		&currentBid.ID,
-		&currentBid.Name,
-		&currentBid.Description,
-		&currentBid.Status,
-		&currentBid.TenderId,
-		&currentBid.AuthorType,
-		&currentBid.AuthorId,
-		&currentBid.Version,
-		&currentBid.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	var bid models.Bid
-	bidQuery := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-	             FROM bid WHERE id = $1`
-	err = r.DB.QueryRow(ctx, bidQuery, bidId).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderId,
-		&bid.AuthorType,
-		&bid.AuthorId,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
-	if err != nil {
+// setBidStatus сохраняет заблокированную версию предложения в истории и переводит предложение
+// в статус status с увеличением версии.
+func (r *PostgresBidRepository) setBidStatus(ctx context.Context, currentBid *models.Bid, status models.BidStatus) (*models.Bid, error) {
+	if err := r.saveBidHistory(ctx, currentBid); err != nil {
 		return nil, err
 	}
+	updateQuery := `UPDATE bid SET status = $1, version = version + 1 WHERE id = $2 RETURNING ` + bidColumns
+	return scanBid(r.conn(ctx).QueryRow(ctx, updateQuery, status, currentBid.ID))
+}
 
-	var maxVersion int
-	versionQuery := `SELECT COALESCE(MAX(version), 0) FROM bid_history WHERE bid_id = $1`
-	err = r.DB.QueryRow(ctx, versionQuery, currentBid.ID).Scan(&maxVersion)
+// EditBid меняет описание и коммерческие условия предложения.
+// Текущая версия блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresBidRepository) EditBid(ctx context.Context, bidId string, expectedVersion int, updateFields map[string]interface{}) (*models.Bid, error) {
+	currentBid, err := r.lockBid(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
-
-	historyInsertQuery := `INSERT INTO bid_history (bid_id, name, description, status, author_type, author_id, version, created_at)
-                          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
-	_, err = r.DB.Exec(
-		ctx,
-		historyInsertQuery,
-		currentBid.ID,
-		currentBid.Name,
-		currentBid.Description,
-		currentBid.Status,
-		currentBid.AuthorType,
-		currentBid.AuthorId,
-		maxVersion+1,
-		currentBid.CreatedAt)
-	if err != nil {
+	if err = checkExpectedVersion(expectedVersion, currentBid.Version); err != nil {
 		return nil, err
 	}
 
@@ -259,122 +428,203 @@ func (r *PostgresBidRepository) EditBid(ctx context.Context, bidId string, updat
 		argIndex++
 	}
 
+	terms := currentBid.BidTerms
+	if value, ok := updateFields["priceAmount"]; ok {
+		if terms.PriceAmount, err = parseOptionalNumber("priceAmount", value); err != nil {
+			return nil, err
+		}
+		updates = append(updates, fmt.Sprintf("price_amount = $%d", argIndex))
+		args = append(args, terms.PriceAmount)
+		argIndex++
+	}
+
+	if value, ok := updateFields["currency"]; ok {
+		if terms.Currency, err = parseOptionalString("currency", value); err != nil {
+			return nil, err
+		}
+		updates = append(updates, fmt.Sprintf("currency = $%d", argIndex))
+		args = append(args, terms.Currency)
+		argIndex++
+	}
+
+	if value, ok := updateFields["timeframeDays"]; ok {
+		if terms.TimeframeDays, err = parseOptionalInt("timeframeDays", value); err != nil {
+			return nil, err
+		}
+		updates = append(updates, fmt.Sprintf("timeframe_days = $%d", argIndex))
+		args = append(args, terms.TimeframeDays)
+		argIndex++
+	}
+
+	if value, ok := updateFields["warrantyTerms"]; ok {
+		if terms.WarrantyTerms, err = parseOptionalString("warrantyTerms", value); err != nil {
+			return nil, err
+		}
+		updates = append(updates, fmt.Sprintf("warranty_terms = $%d", argIndex))
+		args = append(args, terms.WarrantyTerms)
+		argIndex++
+	}
+
+	if value, ok := updateFields["lotPrices"]; ok {
+		if terms.LotPrices, err = parseLotPrices(value); err != nil {
+			return nil, err
+		}
+		if err = r.checkBidLots(ctx, currentBid.TenderId, terms.LotPrices); err != nil {
+			return nil, err
+		}
+		updates = append(updates, fmt.Sprintf("lot_prices = $%d", argIndex))
+		args = append(args, terms.LotPrices)
+		argIndex++
+	}
+
+	if err = models.ValidateBidTerms(terms); err != nil {
+		return nil, err
+	}
+
 	if len(updates) == 0 {
 		return nil, models.NewErrorResponse(http.StatusBadRequest, "no valid fields to update")
 	}
 
+	if err = r.saveBidHistory(ctx, currentBid); err != nil {
+		return nil, err
+	}
+
 	updates = append(updates, "version = version + 1")
-	updateQuery := fmt.Sprintf("UPDATE bid SET %s WHERE id = $1 RETURNING id, name, description, status, tender_id, author_type, author_id, version, created_at", strings.Join(updates, ", "))
-
-	var updatedBid models.Bid
-	err = r.DB.QueryRow(ctx, updateQuery, args...).Scan(
-		&updatedBid.ID,
-		&updatedBid.Name,
-		&updatedBid.Description,
-		&updatedBid.Status,
-		&updatedBid.TenderId,
-		&updatedBid.AuthorType,
-		&updatedBid.AuthorId,
-		&updatedBid.Version,
-		&updatedBid.CreatedAt,
-	)
+	updateQuery := fmt.Sprintf("UPDATE bid SET %s WHERE id = $1 RETURNING %s", strings.Join(updates, ", "), bidColumns)
+
+	return scanBid(r.conn(ctx).QueryRow(ctx, updateQuery, args...))
+}
+
+// LowerBidPrice снижает цену опубликованного предложения в указанной валюте, создавая новую версию.
+// Новая цена должна быть строго ниже текущей. Текущая версия блокируется и сохраняется в истории,
+// поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresBidRepository) LowerBidPrice(ctx context.Context, bidId, currency string, priceAmount float64, expectedVersion int) (*models.Bid, error) {
+	currentBid, err := r.lockBid(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
-	return &updatedBid, nil
+	if err = checkExpectedVersion(expectedVersion, currentBid.Version); err != nil {
+		return nil, err
+	}
+	if currentBid.Status != models.PublishedBid {
+		return nil, models.NewErrorResponse(http.StatusConflict, "only published bids can take part in the auction")
+	}
+	if currentBid.PriceAmount == nil || currentBid.Currency == nil || *currentBid.Currency != currency {
+		return nil, models.NewErrorResponse(http.StatusConflict, fmt.Sprintf("bid price must be specified in the auction currency %s", currency))
+	}
+	if priceAmount >= *currentBid.PriceAmount {
+		return nil, models.NewErrorResponse(http.StatusConflict, "new price must be lower than the previous price of the bid")
+	}
+
+	if err = r.saveBidHistory(ctx, currentBid); err != nil {
+		return nil, err
+	}
+
+	updateQuery := `UPDATE bid SET price_amount = $2, version = version + 1 WHERE id = $1 RETURNING ` + bidColumns
+	return scanBid(r.conn(ctx).QueryRow(ctx, updateQuery, bidId, priceAmount))
 }
 
-// SubmitBidDecision отправляет решение по предложению.
-func (r *PostgresBidRepository) SubmitBidDecision(ctx context.Context, bidId, decision string) (*models.Bid, error) {
-	var bid models.Bid
-	bidQuery := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-	             FROM bid WHERE id = $1`
-	err := r.DB.QueryRow(ctx, bidQuery, bidId).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderId,
-		&bid.AuthorType,
-		&bid.AuthorId,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
+// SubmitBidDecision сохраняет решение ответственного по предложению и пересчитывает итог голосования.
+// Предложение отклоняется при первом решении Rejected и согласовывается, когда число решений Approved
+// достигает кворума: min(3, количество ответственных за организацию тендера).
+// Решение принимается только по опубликованному предложению к незакрытому тендеру. Предложение и тендер
+// блокируются на время проверки и подсчёта, чтобы решение не изменило уже согласованное предложение
+// и по тендеру не было согласовано второе предложение, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresBidRepository) SubmitBidDecision(ctx context.Context, bidId, userId, decision string) (*models.BidDecisionResult, error) {
+	bid, err := r.lockBid(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
-	bid.Status = models.BidStatus(decision)
+	if bid.Status != models.PublishedBid {
+		return nil, models.NewErrorResponse(http.StatusConflict, "decisions can only be submitted for published bids")
+	}
+	var tenderStatus models.TenderStatus
+	err = r.conn(ctx).QueryRow(ctx, `SELECT status FROM tender WHERE id = $1 FOR UPDATE`, bid.TenderId).Scan(&tenderStatus)
+	if err != nil {
+		return nil, err
+	}
+	if tenderStatus == models.ClosedTender {
+		return nil, models.NewErrorResponse(http.StatusConflict, "tender is already closed")
+	}
 
-	updateBidQuery := `UPDATE bid SET status = $1 WHERE id = $2`
-	_, err = r.DB.Exec(ctx, updateBidQuery, bid.Status, bidId)
+	insertDecisionQuery := `INSERT INTO bid_decision (id, bid_id, user_id, decision, created_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err = r.conn(ctx).Exec(ctx, insertDecisionQuery, uuid.New().String(), bidId, userId, decision, time.Now().UTC())
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, models.NewErrorResponse(http.StatusConflict, "you have already submitted a decision for this bid")
+		}
 		return nil, err
 	}
 
-	if models.BidDecision(decision) == models.ApprovedBid {
-		updateTenderQuery := `UPDATE tender SET status = $1 WHERE id = $2`
-		_, err = r.DB.Exec(ctx, updateTenderQuery, models.ClosedTender, bid.TenderId)
-		if err != nil {
+	var tally models.BidDecisionTally
+	tallyQuery := `
+		SELECT COUNT(*) FILTER (WHERE decision = $2), COUNT(*) FILTER (WHERE decision = $3)
+		FROM bid_decision WHERE bid_id = $1`
+	err = r.conn(ctx).QueryRow(ctx, tallyQuery, bidId, models.ApprovedBid, models.RejectedBid).Scan(&tally.Approvals, &tally.Rejections)
+	if err != nil {
+		return nil, err
+	}
+
+	quorumQuery := `
+		SELECT LEAST(3, COUNT(*))
+		FROM organization_responsible orr
+		JOIN tender t ON t.organization_id = orr.organization_id
+		WHERE t.id = $1`
+	err = r.conn(ctx).QueryRow(ctx, quorumQuery, bid.TenderId).Scan(&tally.Quorum)
+	if err != nil {
+		return nil, err
+	}
+
+	if newStatus := bidDecisionStatus(tally); newStatus != "" && newStatus != bid.Status {
+		if bid, err = r.setBidStatus(ctx, bid, newStatus); err != nil {
 			return nil, err
 		}
 	}
 
-	return &bid, nil
+	return &models.BidDecisionResult{Bid: *bid, Tally: tally}, nil
+}
+
+// bidDecisionStatus возвращает статус предложения по итогам голосования: Rejected при любом решении Rejected,
+// Approved при достижении кворума решениями Approved и пустой статус, пока голосование не завершено.
+func bidDecisionStatus(tally models.BidDecisionTally) models.BidStatus {
+	switch {
+	case tally.Rejections > 0:
+		return models.BidStatus(models.RejectedBid)
+	case tally.Approvals >= tally.Quorum:
+		return models.BidStatus(models.ApprovedBid)
+	}
+	return ""
 }
 
 // SubmitBidFeedback отправляет отзыв на предложение.
 func (r *PostgresBidRepository) SubmitBidFeedback(ctx context.Context, review models.BidReview, bidId string) (*models.Bid, error) {
 	insertQuery := `INSERT INTO bid_review (id, bid_id, description, created_at) VALUES ($1, $2, $3, $4)`
-	_, err := r.DB.Exec(ctx, insertQuery, review.ID, review.BidID, review.Description, review.CreatedAt)
+	_, err := r.conn(ctx).Exec(ctx, insertQuery, review.ID, review.BidID, review.Description, review.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 
-	var bid models.Bid
-	selectQuery := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-                   FROM bid WHERE id = $1`
-	err = r.DB.QueryRow(ctx, selectQuery, bidId).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderId,
-		&bid.AuthorType,
-		&bid.AuthorId,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &bid, nil
+	selectQuery := `SELECT ` + bidColumns + ` FROM bid WHERE id = $1`
+	return scanBid(r.conn(ctx).QueryRow(ctx, selectQuery, bidId))
 }
 
-// RollbackBid откатывает версию предложения.
-func (r *PostgresBidRepository) RollbackBid(ctx context.Context, bidId string, version int) (*models.Bid, error) {
-	var bid models.Bid
-	bidQuery := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-	             FROM bid WHERE id = $1`
-	err := r.DB.QueryRow(ctx, bidQuery, bidId).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderId,
-		&bid.AuthorType,
-		&bid.AuthorId,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
+// RollbackBid откатывает версию предложения вместе с набором его вложений.
+// Текущая версия блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresBidRepository) RollbackBid(ctx context.Context, bidId string, version, expectedVersion int) (*models.Bid, error) {
+	currentBid, err := r.lockBid(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
+	if err = checkExpectedVersion(expectedVersion, currentBid.Version); err != nil {
+		return nil, err
+	}
 
 	var rollbackBid models.Bid
-	query := `SELECT bid_id, name, description, status, author_type, author_id, version, created_at
+	query := `SELECT bid_id, name, description, status, author_type, author_id, version, created_at,
+	                 price_amount, currency, timeframe_days, warranty_terms, lot_prices, attachments
 	          FROM bid_history WHERE bid_id = $1 AND version = $2`
-	err = r.DB.QueryRow(ctx, query, bidId, version).Scan(
+	err = r.conn(ctx).QueryRow(ctx, query, bidId, version).Scan(
 		&rollbackBid.ID,
 		&rollbackBid.Name,
 		&rollbackBid.Description,
@@ -383,15 +633,30 @@ func (r *PostgresBidRepository) RollbackBid(ctx context.Context, bidId string, v
 		&rollbackBid.AuthorId,
 		&rollbackBid.Version,
 		&rollbackBid.CreatedAt,
+		&rollbackBid.PriceAmount,
+		&rollbackBid.Currency,
+		&rollbackBid.TimeframeDays,
+		&rollbackBid.WarrantyTerms,
+		&rollbackBid.LotPrices,
+		&rollbackBid.Attachments,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = r.saveBidHistory(ctx, currentBid); err != nil {
+		return nil, err
+	}
+	if err = bidAttachments.replace(ctx, r.conn(ctx), bidId, rollbackBid.Attachments); err != nil {
+		return nil, err
+	}
+
 	updateQuery := `
-			UPDATE bid SET name = $1, description = $2, status = $3, author_type = $4, author_id = $5, version = version + 1
-			WHERE id = $6 RETURNING id, name, description, status, author_type, author_id, version, created_at`
-	err = r.DB.QueryRow(
+			UPDATE bid SET name = $1, description = $2, status = $3, author_type = $4, author_id = $5,
+			               price_amount = $6, currency = $7, timeframe_days = $8, warranty_terms = $9, lot_prices = $10,
+			               version = version + 1
+			WHERE id = $11 RETURNING ` + bidColumns
+	return scanBid(r.conn(ctx).QueryRow(
 		ctx,
 		updateQuery,
 		rollbackBid.Name,
@@ -399,62 +664,45 @@ func (r *PostgresBidRepository) RollbackBid(ctx context.Context, bidId string, v
 		rollbackBid.Status,
 		rollbackBid.AuthorType,
 		rollbackBid.AuthorId,
-		bidId).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.AuthorType,
-		&bid.AuthorId,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	historyInsertQuery := `INSERT INTO bid_history (bid_id, name, description, status, author_type, author_id, version, created_at)
-                          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
-	_, err = r.DB.Exec(
-		ctx,
-		historyInsertQuery,
-		bid.ID,
-		bid.Name,
-		bid.Description,
-		bid.Status,
-		bid.AuthorType,
-		bid.AuthorId,
-		bid.Version,
-		bid.CreatedAt)
-	if err != nil {
-		return nil, err
-	}
+		rollbackBid.PriceAmount,
+		rollbackBid.Currency,
+		rollbackBid.TimeframeDays,
+		rollbackBid.WarrantyTerms,
+		rollbackBid.LotPrices,
+		bidId))
+}
 
-	return &bid, nil
+// bidReviewOrder - порядок списка отзывов: от новых к старым, при равном времени - по идентификатору.
+var bidReviewOrder = keysetOrder[models.BidReview]{
+	{column: "br.created_at", cast: "timestamp", desc: true, value: func(r *models.BidReview) any { return keysetTime(r.CreatedAt) }},
+	{column: "br.id", cast: "uuid", desc: true, value: func(r *models.BidReview) any { return r.ID }},
 }
 
-// GetBidReviews получает список отзывов на предложение.
-func (r *PostgresBidRepository) GetBidReviews(ctx context.Context, tenderId, authorUsername, requesterUsername string, limit, offset int) ([]models.BidReview, error) {
+// GetBidReviews получает страницу списка отзывов на предложения автора по тендеру.
+func (r *PostgresBidRepository) GetBidReviews(ctx context.Context, tenderId, authorUsername, requesterUsername string, page models.Page) (models.Paged[models.BidReview], error) {
+	filters := []string{
+		"t.id = $1",
+		"b.author_id = (SELECT id FROM employee WHERE username = $2)",
+		`EXISTS (
+			SELECT 1
+			FROM organization_responsible o
+			WHERE o.user_id = (SELECT id FROM employee WHERE username = $3)
+			AND o.organization_id = t.organization_id
+		)`,
+	}
+	tail, args, err := bidReviewOrder.page(filters, []interface{}{tenderId, authorUsername, requesterUsername}, page)
+	if err != nil {
+		return models.Paged[models.BidReview]{}, err
+	}
 	query := `
-   	SELECT br.id, br.description, br.created_at
+		SELECT br.id, br.description, br.created_at
 		FROM bid_review br
 		JOIN bid b ON br.bid_id = b.id
-		JOIN tender t ON b.tender_id = t.id
-		WHERE t.id = $1
-		AND b.author_id = (SELECT id FROM employee WHERE username = $2)
-		AND EXISTS (
-   		SELECT 1
-   		FROM organization_responsible o
-   		WHERE o.user_id = (SELECT id FROM employee WHERE username = $3)
-   		AND o.organization_id = t.organization_id
-		)
-		ORDER BY br.created_at DESC
-		LIMIT $4 OFFSET $5;
-	`
-
-	rows, err := r.DB.Query(ctx, query, tenderId, authorUsername, requesterUsername, limit, offset)
+		JOIN tender t ON b.tender_id = t.id` + tail
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return models.Paged[models.BidReview]{}, err
 	}
 	defer rows.Close()
 
@@ -462,10 +710,138 @@ func (r *PostgresBidRepository) GetBidReviews(ctx context.Context, tenderId, aut
 	for rows.Next() {
 		var review models.BidReview
 		if err := rows.Scan(&review.ID, &review.Description, &review.CreatedAt); err != nil {
-			return nil, err
+			return models.Paged[models.BidReview]{}, err
 		}
 		reviews = append(reviews, review)
 	}
+	if err = rows.Err(); err != nil {
+		return models.Paged[models.BidReview]{}, err
+	}
+	return bidReviewOrder.collect(reviews, page.Limit), nil
+}
+
+// GetBidVersions возвращает все версии предложения вместе с вложениями по возрастанию: снимки из истории и текущую версию.
+func (r *PostgresBidRepository) GetBidVersions(ctx context.Context, bidId string) ([]models.Bid, error) {
+	query := `SELECT ` + bidHistoryColumns + `, h.attachments FROM bid_history h JOIN bid b ON b.id = h.bid_id WHERE h.bid_id = $1
+	          UNION ALL
+	          SELECT ` + bidColumns + `, ` + bidAttachments.snapshot("bid.id") + ` FROM bid WHERE id = $1
+	          ORDER BY version`
+	rows, err := r.conn(ctx).Query(ctx, query, bidId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bids []models.Bid
+	for rows.Next() {
+		bid, err := scanBidVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, *bid)
+	}
+	return bids, rows.Err()
+}
+
+// GetBidVersion возвращает снимок предложения указанной версии вместе с вложениями.
+func (r *PostgresBidRepository) GetBidVersion(ctx context.Context, bidId string, version int) (*models.Bid, error) {
+	query := `SELECT ` + bidHistoryColumns + `, h.attachments FROM bid_history h JOIN bid b ON b.id = h.bid_id WHERE h.bid_id = $1 AND h.version = $2
+	          UNION ALL
+	          SELECT ` + bidColumns + `, ` + bidAttachments.snapshot("bid.id") + ` FROM bid WHERE id = $1 AND version = $2
+	          LIMIT 1`
+	return scanBidVersion(r.conn(ctx).QueryRow(ctx, query, bidId, version))
+}
 
-	return reviews, nil
+// beginBidChange блокирует предложение перед изменением вложений, сверяет ожидаемую версию
+// и сохраняет текущую версию предложения вместе с вложениями в истории.
+func (r *PostgresBidRepository) beginBidChange(ctx context.Context, bidId string, expectedVersion int) error {
+	currentBid, err := r.lockBid(ctx, bidId)
+	if err != nil {
+		return err
+	}
+	if err = checkExpectedVersion(expectedVersion, currentBid.Version); err != nil {
+		return err
+	}
+	return r.saveBidHistory(ctx, currentBid)
+}
+
+// commitBidChange увеличивает версию предложения после изменения вложений и возвращает новую версию вместе с вложениями.
+func (r *PostgresBidRepository) commitBidChange(ctx context.Context, bidId string) (*models.Bid, error) {
+	updateQuery := `UPDATE bid SET version = version + 1 WHERE id = $1
+	                RETURNING ` + bidColumns + `, ` + bidAttachments.snapshot("bid.id")
+	return scanBidVersion(r.conn(ctx).QueryRow(ctx, updateQuery, bidId))
+}
+
+// checkBidLots проверяет, что все лоты, по которым указаны цены, принадлежат тендеру предложения.
+func (r *PostgresBidRepository) checkBidLots(ctx context.Context, tenderId string, lotPrices []models.BidLotPrice) error {
+	if len(lotPrices) == 0 {
+		return nil
+	}
+	lotIds := make([]string, 0, len(lotPrices))
+	for _, lotPrice := range lotPrices {
+		lotIds = append(lotIds, lotPrice.LotID)
+	}
+
+	var found int
+	query := `SELECT COUNT(*) FROM tender_lot WHERE tender_id = $1 AND id::text = ANY($2)`
+	if err := r.conn(ctx).QueryRow(ctx, query, tenderId, lotIds).Scan(&found); err != nil {
+		return err
+	}
+	if found != len(lotIds) {
+		return models.NewErrorResponse(http.StatusBadRequest, "invalid lotPrices, lot not found in this tender")
+	}
+	return nil
+}
+
+// parseLotPrices разбирает цены по лотам из тела запроса на редактирование: массив или null.
+func parseLotPrices(value interface{}) ([]models.BidLotPrice, error) {
+	if value == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var lotPrices []models.BidLotPrice
+	if err = json.Unmarshal(raw, &lotPrices); err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid lotPrices, must be an array of {lotId, priceAmount} or null")
+	}
+	return lotPrices, nil
+}
+
+// parseOptionalNumber разбирает необязательное число из тела запроса на редактирование.
+func parseOptionalNumber(field string, value interface{}) (*float64, error) {
+	if value == nil {
+		return nil, nil
+	}
+	number, ok := value.(float64)
+	if !ok {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid %s, must be a number or null", field))
+	}
+	return &number, nil
+}
+
+// parseOptionalInt разбирает необязательное целое число из тела запроса на редактирование.
+func parseOptionalInt(field string, value interface{}) (*int, error) {
+	number, err := parseOptionalNumber(field, value)
+	if err != nil || number == nil {
+		return nil, err
+	}
+	if *number != math.Trunc(*number) {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid %s, must be an integer or null", field))
+	}
+	integer := int(*number)
+	return &integer, nil
+}
+
+// parseOptionalString разбирает необязательную строку из тела запроса на редактирование.
+func parseOptionalString(field string, value interface{}) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid %s, must be a string or null", field))
+	}
+	return &str, nil
 }
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+)
+
+func TestBidDecisionStatus(t *testing.T) {
+	approved := models.BidStatus(models.ApprovedBid)
+	rejected := models.BidStatus(models.RejectedBid)
+
+	tests := []struct {
+		name  string
+		tally models.BidDecisionTally
+		want  models.BidStatus
+	}{
+		{"no decisions", models.BidDecisionTally{Quorum: 3}, ""},
+		{"approvals below quorum", models.BidDecisionTally{Approvals: 2, Quorum: 3}, ""},
+		{"approvals reach quorum", models.BidDecisionTally{Approvals: 3, Quorum: 3}, approved},
+		// Кворум - min(3, число ответственных): при двух ответственных достаточно двух решений.
+		{"quorum of two responsibles", models.BidDecisionTally{Approvals: 2, Quorum: 2}, approved},
+		{"single responsible", models.BidDecisionTally{Approvals: 1, Quorum: 1}, approved},
+		{"single rejection", models.BidDecisionTally{Rejections: 1, Quorum: 3}, rejected},
+		{"rejection outweighs quorum of approvals", models.BidDecisionTally{Approvals: 3, Rejections: 1, Quorum: 3}, rejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bidDecisionStatus(tt.tally); got != tt.want {
+				t.Errorf("bidDecisionStatus(%+v) = %q, want %q", tt.tally, got, tt.want)
+			}
+		})
+	}
+}
+
+// wantConflict проверяет, что err - ошибка 409.
+func wantConflict(t *testing.T, err error) {
+	t.Helper()
+	var errResp *models.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.StatusCode != http.StatusConflict {
+		t.Errorf("error = %v, want 409", err)
+	}
+}
+
+func TestSubmitBidDecision(t *testing.T) {
+	const (
+		organizationId = "7c2e9d41-58a3-4b6f-8e0d-92f1a6c3b7e5"
+		authorId       = "e1a4b7c0-2d35-4f68-9b1e-4c7d0a3f6b29"
+		tenderId       = "3a8f1c27-6e4b-4d09-b2f5-81c7e9a04d36"
+		otherTenderId  = "9d2c5f81-4a7e-4b30-86d1-e5f0b3c9a274"
+		winnerBidId    = "c5d2e8f0-9b13-4a67-8c4e-2f6a1d7b3e95"
+		loserBidId     = "5b8e1d4a-7c20-4f93-a6b5-d0e3f7c1a982"
+		rejectedBidId  = "f7a0c3e6-1b49-4d82-95c7-3e6b9d2f0a14"
+	)
+	responsibles := []string{
+		"0b6f7a3c-1d52-4f1e-9a57-3c1f2b8e4d10",
+		"2f5c8a1e-7b34-4d96-a0e7-b3d6f9c2e581",
+		"4a7d0b3e-9c56-4f18-b2a9-d5e8c1f4a703",
+		"6c9f2d5a-1e78-4b3a-84cb-f7a0e3b6c925",
+	}
+	ctx := context.Background()
+	pool, migration := newTestDB(t)
+	if err := migration.Up(); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	seed := []string{
+		`INSERT INTO employee (id, username) VALUES ('` + authorId + `', 'author')`,
+		`INSERT INTO organization (id, name, type) VALUES ('` + organizationId + `', 'Org', 'LLC')`,
+	}
+	for i, userId := range responsibles {
+		seed = append(seed,
+			`INSERT INTO employee (id, username) VALUES ('`+userId+`', 'responsible`+strconv.Itoa(i+1)+`')`,
+			`INSERT INTO organization_responsible (organization_id, user_id) VALUES ('`+organizationId+`', '`+userId+`')`)
+	}
+	for _, id := range []string{tenderId, otherTenderId} {
+		seed = append(seed, `INSERT INTO tender (id, name, description, service_type, status, organization_id, creator_username)
+			VALUES ('`+id+`', 'Tender', 'd', 'Delivery', 'Published', '`+organizationId+`', 'responsible1')`)
+	}
+	for bidId, bidTenderId := range map[string]string{winnerBidId: tenderId, loserBidId: tenderId, rejectedBidId: otherTenderId} {
+		seed = append(seed, `INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id)
+			VALUES ('`+bidId+`', 'Bid', 'd', 'Published', '`+bidTenderId+`', 'User', '`+authorId+`')`)
+	}
+	seedTestDB(t, pool, seed...)
+
+	uow := db.NewUnitOfWork(pool)
+	bids := NewPostgresBidRepository(pool)
+	tenders := NewPostgresTenderRepository(pool)
+	decide := func(bidId, userId string, decision models.BidDecision) (*models.BidDecisionResult, error) {
+		var result *models.BidDecisionResult
+		err := uow.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			result, err = bids.SubmitBidDecision(ctx, bidId, userId, string(decision))
+			return err
+		})
+		return result, err
+	}
+
+	// При четырёх ответственных кворум равен трём.
+	for i, userId := range responsibles[:3] {
+		result, err := decide(winnerBidId, userId, models.ApprovedBid)
+		if err != nil {
+			t.Fatalf("approval %d: %v", i+1, err)
+		}
+		wantStatus := models.PublishedBid
+		if i == 2 {
+			wantStatus = models.BidStatus(models.ApprovedBid)
+		}
+		if result.Status != wantStatus || result.Tally.Approvals != i+1 || result.Tally.Quorum != 3 {
+			t.Errorf("approval %d: status %s, tally %+v", i+1, result.Status, result.Tally)
+		}
+
+		// Повторное решение того же ответственного отклоняется.
+		if i == 0 {
+			_, err = decide(winnerBidId, userId, models.RejectedBid)
+			wantConflict(t, err)
+		}
+	}
+	if _, err := tenders.CloseTender(ctx, tenderId); err != nil {
+		t.Fatalf("close tender: %v", err)
+	}
+
+	t.Run("late rejection does not change approved bid", func(t *testing.T) {
+		_, err := decide(winnerBidId, responsibles[3], models.RejectedBid)
+		wantConflict(t, err)
+	})
+
+	t.Run("no decisions on bids of closed tender", func(t *testing.T) {
+		_, err := decide(loserBidId, responsibles[0], models.ApprovedBid)
+		wantConflict(t, err)
+	})
+
+	t.Run("single rejection rejects bid", func(t *testing.T) {
+		result, err := decide(rejectedBidId, responsibles[0], models.RejectedBid)
+		if err != nil {
+			t.Fatalf("SubmitBidDecision() error = %v", err)
+		}
+		if result.Status != models.BidStatus(models.RejectedBid) || result.Tally.Rejections != 1 {
+			t.Errorf("status %s, tally %+v, want Rejected with one rejection", result.Status, result.Tally)
+		}
+	})
+}
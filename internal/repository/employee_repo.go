@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// employeeColumns - список столбцов таблицы employee в порядке, ожидаемом scanEmployee.
+const employeeColumns = `id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), created_at, updated_at`
+
+// EmployeeRepository - интерфейс для работы с сотрудниками.
+type EmployeeRepository interface {
+	CreateEmployee(ctx context.Context, employeeReq models.EmployeeRequest, passwordHash *string) (*models.Employee, error)
+	GetEmployee(ctx context.Context, employeeId string) (*models.Employee, error)
+	GetEmployees(ctx context.Context, limit, offset int) ([]models.Employee, error)
+	UpdateEmployee(ctx context.Context, employeeId string, updateFields map[string]interface{}) (*models.Employee, error)
+	DeleteEmployee(ctx context.Context, employeeId string) error
+	LockEmployees(ctx context.Context) (int, error)
+}
+
+// PostgresEmployeeRepository - реализация EmployeeRepository для базы данных.
+type PostgresEmployeeRepository struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresEmployeeRepository создаёт новый экземпляр PostgresEmployeeRepository.
+func NewPostgresEmployeeRepository(db *pgxpool.Pool) *PostgresEmployeeRepository {
+	return &PostgresEmployeeRepository{DB: db}
+}
+
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresEmployeeRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// scanEmployee считывает сотрудника из строки результата, выбранной по employeeColumns.
+func scanEmployee(row pgx.Row) (*models.Employee, error) {
+	var employee models.Employee
+	err := row.Scan(
+		&employee.ID,
+		&employee.Username,
+		&employee.FirstName,
+		&employee.LastName,
+		&employee.CreatedAt,
+		&employee.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// collectEmployees считывает всех сотрудников из результата запроса.
+func collectEmployees(rows pgx.Rows) ([]models.Employee, error) {
+	defer rows.Close()
+
+	var employees []models.Employee
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			return nil, err
+		}
+		employees = append(employees, *employee)
+	}
+	return employees, rows.Err()
+}
+
+// CreateEmployee создаёт нового сотрудника.
+func (r *PostgresEmployeeRepository) CreateEmployee(ctx context.Context, employeeReq models.EmployeeRequest, passwordHash *string) (*models.Employee, error) {
+	now := time.Now().UTC()
+	newEmployee := models.Employee{
+		ID:        uuid.New().String(),
+		Username:  employeeReq.Username,
+		FirstName: employeeReq.FirstName,
+		LastName:  employeeReq.LastName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	insertQuery := `INSERT INTO employee (id, username, first_name, last_name, password_hash, created_at, updated_at)
+	                VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.conn(ctx).Exec(
+		ctx,
+		insertQuery,
+		newEmployee.ID,
+		newEmployee.Username,
+		newEmployee.FirstName,
+		newEmployee.LastName,
+		passwordHash,
+		newEmployee.CreatedAt,
+		newEmployee.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, models.NewErrorResponse(http.StatusConflict, "user with this username already exists")
+		}
+		return nil, err
+	}
+	return &newEmployee, nil
+}
+
+// LockEmployees блокирует добавление сотрудников до конца текущей транзакции и возвращает их число.
+// Блокировка не даёт параллельным запросам одновременно зарегистрировать первого сотрудника,
+// поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresEmployeeRepository) LockEmployees(ctx context.Context) (int, error) {
+	if _, err := r.conn(ctx).Exec(ctx, `LOCK TABLE employee IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return 0, err
+	}
+	var count int
+	err := r.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM employee`).Scan(&count)
+	return count, err
+}
+
+// GetEmployee возвращает сотрудника по id.
+func (r *PostgresEmployeeRepository) GetEmployee(ctx context.Context, employeeId string) (*models.Employee, error) {
+	query := `SELECT ` + employeeColumns + ` FROM employee WHERE id = $1`
+	return scanEmployee(r.conn(ctx).QueryRow(ctx, query, employeeId))
+}
+
+// GetEmployees возвращает список сотрудников.
+func (r *PostgresEmployeeRepository) GetEmployees(ctx context.Context, limit, offset int) ([]models.Employee, error) {
+	query := `SELECT ` + employeeColumns + ` FROM employee ORDER BY username LIMIT $1 OFFSET $2`
+	rows, err := r.conn(ctx).Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return collectEmployees(rows)
+}
+
+// UpdateEmployee меняет данные сотрудника.
+// Поддерживаются поля firstName, lastName и passwordHash.
+func (r *PostgresEmployeeRepository) UpdateEmployee(ctx context.Context, employeeId string, updateFields map[string]interface{}) (*models.Employee, error) {
+	columns := map[string]string{
+		"firstName":    "first_name",
+		"lastName":     "last_name",
+		"passwordHash": "password_hash",
+	}
+
+	var updates []string
+	var args []interface{}
+	argIndex := 1
+	for _, field := range []string{"firstName", "lastName", "passwordHash"} {
+		if value, ok := updateFields[field]; ok {
+			updates = append(updates, fmt.Sprintf("%s = $%d", columns[field], argIndex))
+			args = append(args, value)
+			argIndex++
+		}
+	}
+	updates = append(updates, "updated_at = CURRENT_TIMESTAMP")
+
+	updateQuery := fmt.Sprintf("UPDATE employee SET %s WHERE id = $%d RETURNING %s", strings.Join(updates, ", "), argIndex, employeeColumns)
+	args = append(args, employeeId)
+
+	return scanEmployee(r.conn(ctx).QueryRow(ctx, updateQuery, args...))
+}
+
+// DeleteEmployee удаляет сотрудника.
+func (r *PostgresEmployeeRepository) DeleteEmployee(ctx context.Context, employeeId string) error {
+	tag, err := r.conn(ctx).Exec(ctx, `DELETE FROM employee WHERE id = $1`, employeeId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// criterionColumns - список столбцов таблицы tender_criterion в порядке, ожидаемом scanCriterion.
+const criterionColumns = `id, tender_id, name, kind, weight, created_at`
+
+// EvaluationRepository - интерфейс для работы с критериями и оценками предложений.
+type EvaluationRepository interface {
+	GetCriteria(ctx context.Context, tenderId string) ([]models.EvaluationCriterion, error)
+	CreateCriterion(ctx context.Context, tenderId string, criterionReq models.EvaluationCriterionRequest) (*models.EvaluationCriterion, error)
+	DeleteCriterion(ctx context.Context, tenderId, criterionId string) (*models.EvaluationCriterion, error)
+	SaveScores(ctx context.Context, bidId, userId string, scores []models.BidScoreRequest) ([]models.BidScore, error)
+	GetPublishedBids(ctx context.Context, tenderId string) ([]models.Bid, error)
+	GetScores(ctx context.Context, tenderId string) ([]models.BidScore, error)
+}
+
+// PostgresEvaluationRepository - реализация EvaluationRepository для базы данных.
+type PostgresEvaluationRepository struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresEvaluationRepository создаёт новый экземпляр PostgresEvaluationRepository.
+func NewPostgresEvaluationRepository(db *pgxpool.Pool) *PostgresEvaluationRepository {
+	return &PostgresEvaluationRepository{DB: db}
+}
+
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresEvaluationRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// scanCriterion считывает критерий из строки результата, выбранной по criterionColumns.
+func scanCriterion(row pgx.Row) (*models.EvaluationCriterion, error) {
+	var criterion models.EvaluationCriterion
+	err := row.Scan(
+		&criterion.ID,
+		&criterion.TenderID,
+		&criterion.Name,
+		&criterion.Kind,
+		&criterion.Weight,
+		&criterion.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &criterion, nil
+}
+
+// GetCriteria возвращает критерии оценки тендера в порядке создания.
+func (r *PostgresEvaluationRepository) GetCriteria(ctx context.Context, tenderId string) ([]models.EvaluationCriterion, error) {
+	query := `SELECT ` + criterionColumns + ` FROM tender_criterion WHERE tender_id = $1 ORDER BY created_at, id`
+	rows, err := r.conn(ctx).Query(ctx, query, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	criteria := []models.EvaluationCriterion{}
+	for rows.Next() {
+		criterion, err := scanCriterion(rows)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, *criterion)
+	}
+	return criteria, rows.Err()
+}
+
+// CreateCriterion добавляет критерий оценки в тендер.
+// Критерии цены и срока выполнения могут быть заданы для тендера только по одному разу.
+func (r *PostgresEvaluationRepository) CreateCriterion(ctx context.Context, tenderId string, criterionReq models.EvaluationCriterionRequest) (*models.EvaluationCriterion, error) {
+	newCriterion := models.EvaluationCriterion{
+		ID:        uuid.New().String(),
+		TenderID:  tenderId,
+		Name:      criterionReq.Name,
+		Kind:      criterionReq.Kind,
+		Weight:    criterionReq.Weight,
+		CreatedAt: time.Now().UTC(),
+	}
+	insertQuery := `INSERT INTO tender_criterion (` + criterionColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.conn(ctx).Exec(
+		ctx,
+		insertQuery,
+		newCriterion.ID,
+		newCriterion.TenderID,
+		newCriterion.Name,
+		newCriterion.Kind,
+		newCriterion.Weight,
+		newCriterion.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, models.NewErrorResponse(http.StatusConflict, "criterion of this kind already exists for this tender")
+		}
+		return nil, err
+	}
+	return &newCriterion, nil
+}
+
+// DeleteCriterion удаляет критерий оценки тендера вместе с оценками по нему и возвращает удалённый критерий.
+func (r *PostgresEvaluationRepository) DeleteCriterion(ctx context.Context, tenderId, criterionId string) (*models.EvaluationCriterion, error) {
+	query := `DELETE FROM tender_criterion WHERE tender_id = $1 AND id = $2 RETURNING ` + criterionColumns
+	return scanCriterion(r.conn(ctx).QueryRow(ctx, query, tenderId, criterionId))
+}
+
+// SaveScores сохраняет оценки предложения пользователем по пользовательским критериям тендера.
+// Повторная оценка по тому же критерию заменяет предыдущую.
+func (r *PostgresEvaluationRepository) SaveScores(ctx context.Context, bidId, userId string, scores []models.BidScoreRequest) ([]models.BidScore, error) {
+	checkQuery := `SELECT EXISTS(
+		SELECT 1 FROM tender_criterion c
+		JOIN bid b ON b.tender_id = c.tender_id
+		WHERE b.id = $1 AND c.id::text = $2 AND c.kind = $3)`
+	upsertQuery := `INSERT INTO bid_score (bid_id, criterion_id, user_id, score, updated_at)
+	                VALUES ($1, $2, $3, $4, $5)
+	                ON CONFLICT (bid_id, criterion_id, user_id) DO UPDATE SET score = EXCLUDED.score, updated_at = EXCLUDED.updated_at`
+
+	now := time.Now().UTC()
+	saved := make([]models.BidScore, 0, len(scores))
+	for _, score := range scores {
+		var exists bool
+		if err := r.conn(ctx).QueryRow(ctx, checkQuery, bidId, score.CriterionID, models.CustomCriterion).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid criterionId, must be a custom criterion of this tender")
+		}
+
+		if _, err := r.conn(ctx).Exec(ctx, upsertQuery, bidId, score.CriterionID, userId, score.Score, now); err != nil {
+			return nil, err
+		}
+		saved = append(saved, models.BidScore{
+			BidID:       bidId,
+			CriterionID: score.CriterionID,
+			UserID:      userId,
+			Score:       score.Score,
+			UpdatedAt:   now,
+		})
+	}
+	return saved, nil
+}
+
+// GetPublishedBids возвращает опубликованные предложения по тендеру, участвующие в оценке.
+func (r *PostgresEvaluationRepository) GetPublishedBids(ctx context.Context, tenderId string) ([]models.Bid, error) {
+	query := `SELECT ` + bidColumns + ` FROM bid WHERE tender_id = $1 AND status = $2 ORDER BY name`
+	rows, err := r.conn(ctx).Query(ctx, query, tenderId, models.PublishedBid)
+	if err != nil {
+		return nil, err
+	}
+	return collectBids(rows)
+}
+
+// GetScores возвращает все оценки опубликованных предложений по тендеру.
+func (r *PostgresEvaluationRepository) GetScores(ctx context.Context, tenderId string) ([]models.BidScore, error) {
+	query := `
+		SELECT s.bid_id, s.criterion_id, s.user_id, s.score, s.updated_at
+		FROM bid_score s
+		JOIN bid b ON b.id = s.bid_id
+		WHERE b.tender_id = $1 AND b.status = $2`
+	rows, err := r.conn(ctx).Query(ctx, query, tenderId, models.PublishedBid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []models.BidScore
+	for rows.Next() {
+		var score models.BidScore
+		if err := rows.Scan(&score.BidID, &score.CriterionID, &score.UserID, &score.Score, &score.UpdatedAt); err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+	return scores, rows.Err()
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+)
+
+// errKeysetMismatch возвращается, если число значений курсора не совпадает с числом ключей сортировки.
+var errKeysetMismatch = errors.New("cursor does not match list sort order")
+
+// keysetKey - ключ сортировки списка элементов T для постраничного вывода по курсору.
+type keysetKey[T any] struct {
+	column    string       // Выражение столбца
+	cast      string       // Тип, к которому приводится значение курсора
+	desc      bool         // Сортировка по убыванию
+	nullsLast bool         // Столбец допускает NULL, такие элементы выводятся в конце
+	value     func(*T) any // Значение ключа у элемента: строка, число или nil
+}
+
+// keysetOrder - порядок сортировки списка. Последний ключ должен быть уникальным, чтобы порядок был полным,
+// и тогда страницы по курсору не пропускают и не повторяют элементы при изменениях списка между запросами.
+type keysetOrder[T any] []keysetKey[T]
+
+// orderBy возвращает выражение ORDER BY.
+func (o keysetOrder[T]) orderBy() string {
+	parts := make([]string, len(o))
+	for i, key := range o {
+		parts[i] = key.column
+		if key.desc {
+			parts[i] += " DESC"
+		}
+		if key.nullsLast {
+			parts[i] += " NULLS LAST"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// after возвращает условие выбора элементов, следующих в порядке o за элементом с ключами values,
+// и его параметры, нумерация которых начинается с argIndex.
+func (o keysetOrder[T]) after(values []any, argIndex int) (string, []interface{}, error) {
+	if len(values) != len(o) {
+		return "", nil, errKeysetMismatch
+	}
+
+	var args []interface{}
+	params := make([]string, len(o))
+	param := func(i int) string {
+		if params[i] == "" {
+			params[i] = fmt.Sprintf("$%d::%s", argIndex, o[i].cast)
+			args = append(args, values[i])
+			argIndex++
+		}
+		return params[i]
+	}
+
+	var disjuncts []string
+	var equal []string
+	for i, key := range o {
+		if values[i] != nil {
+			operator := ">"
+			if key.desc {
+				operator = "<"
+			}
+			next := fmt.Sprintf("%s %s %s", key.column, operator, param(i))
+			if key.nullsLast {
+				next = fmt.Sprintf("(%s OR %s IS NULL)", next, key.column)
+			}
+			disjuncts = append(disjuncts, "("+strings.Join(append(equal, next), " AND ")+")")
+			equal = append(equal, fmt.Sprintf("%s = %s", key.column, param(i)))
+		} else {
+			equal = append(equal, key.column+" IS NULL")
+		}
+	}
+	if len(disjuncts) == 0 {
+		return "FALSE", args, nil
+	}
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args, nil
+}
+
+// page дополняет условия filters и параметры args запроса выбором страницы page и возвращает окончание запроса:
+// WHERE, ORDER BY, LIMIT и OFFSET. Выбирается на один элемент больше, чем в странице, чтобы определить, есть ли
+// следующая; лишний элемент отбрасывает collect.
+func (o keysetOrder[T]) page(filters []string, args []interface{}, page models.Page) (string, []interface{}, error) {
+	if len(page.After) > 0 {
+		predicate, afterArgs, err := o.after(page.After, len(args)+1)
+		if err != nil {
+			return "", nil, err
+		}
+		filters = append(filters, predicate)
+		args = append(args, afterArgs...)
+	}
+
+	var query string
+	if len(filters) > 0 {
+		query = " WHERE " + strings.Join(filters, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", o.orderBy(), len(args)+1, len(args)+2)
+	return query, append(args, page.Limit+1, page.Offset), nil
+}
+
+// collect обрезает выбранные по page элементы до размера страницы и определяет ключи
+// последнего элемента, если за страницей есть ещё элементы.
+func (o keysetOrder[T]) collect(items []T, limit int) models.Paged[T] {
+	if items == nil {
+		items = []T{}
+	}
+	if len(items) <= limit {
+		return models.Paged[T]{Items: items}
+	}
+
+	items = items[:limit]
+	last := &items[limit-1]
+	next := make([]any, len(o))
+	for i, key := range o {
+		next[i] = key.value(last)
+	}
+	return models.Paged[T]{Items: items, Next: next}
+}
+
+// descending возвращает ключ key с сортировкой по убыванию.
+func descending[T any](key keysetKey[T]) keysetKey[T] {
+	key.desc = true
+	return key
+}
+
+// keysetTime представляет время как значение ключа сортировки.
+func keysetTime(t time.Time) any {
+	return t.UTC().Format(time.RFC3339Nano)
+}
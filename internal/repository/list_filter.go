@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// Условия фильтра по организации для списков: %s заменяется параметром с массивом идентификаторов организаций.
+const (
+	tenderOrganizationCondition = "organization_id = ANY(%s)"
+	bidOrganizationCondition    = "author_type = 'Organization' AND author_id IN " +
+		"(SELECT user_id FROM organization_responsible WHERE organization_id = ANY(%s))"
+)
+
+// appendListFilter дополняет условия filters и параметры args запроса общими фильтрами списка: по статусу,
+// организации и дате создания. Значения фильтров должны быть проверены заранее.
+func appendListFilter(filters []string, args []interface{}, filter models.ListFilter, organizationCondition string) ([]string, []interface{}) {
+	if len(filter.Statuses) > 0 {
+		args = append(args, pq.Array(filter.Statuses))
+		filters = append(filters, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+
+	if len(filter.OrganizationIDs) > 0 {
+		args = append(args, pq.Array(filter.OrganizationIDs))
+		filters = append(filters, fmt.Sprintf(organizationCondition, fmt.Sprintf("$%d::uuid[]", len(args))))
+	}
+
+	if filter.CreatedAfter != nil {
+		args = append(args, *filter.CreatedAfter)
+		filters = append(filters, fmt.Sprintf("created_at > $%d", len(args)))
+	}
+
+	if filter.CreatedBefore != nil {
+		args = append(args, *filter.CreatedBefore)
+		filters = append(filters, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	return filters, args
+}
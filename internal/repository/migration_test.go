@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testPostgresConnEnv - переменная окружения со строкой подключения к отдельной тестовой базе данных.
+// Тесты с базой данных пропускаются, если она не задана. Схема public тестовой базы пересоздаётся.
+const testPostgresConnEnv = "TEST_POSTGRES_CONN"
+
+// newTestDB пересоздаёт схему тестовой базы данных и возвращает пул соединений и экземпляр migrate,
+// которым тест применяет нужные ему миграции.
+func newTestDB(t *testing.T) (*pgxpool.Pool, *migrate.Migrate) {
+	t.Helper()
+	conn := os.Getenv(testPostgresConnEnv)
+	if conn == "" {
+		t.Skipf("%s is not set", testPostgresConnEnv)
+	}
+
+	pool, err := pgxpool.New(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	resetQuery := `DROP SCHEMA public CASCADE; CREATE SCHEMA public; CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`
+	if _, err = pool.Exec(context.Background(), resetQuery); err != nil {
+		t.Fatalf("reset schema: %v", err)
+	}
+
+	migration, err := migrate.New("file://../../migration", conn)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	t.Cleanup(func() { migration.Close() })
+	return pool, migration
+}
+
+// seedTestDB выполняет запросы, заполняющие тестовую базу данных.
+func seedTestDB(t *testing.T, pool *pgxpool.Pool, queries ...string) {
+	t.Helper()
+	for _, query := range queries {
+		if _, err := pool.Exec(context.Background(), query); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+}
+
+// historyVersions возвращает версии из истории сущности entityId в порядке возрастания.
+func historyVersions(t *testing.T, pool *pgxpool.Pool, table, idColumn, entityId string) []int {
+	t.Helper()
+	rows, err := pool.Query(context.Background(),
+		`SELECT version FROM `+table+` WHERE `+idColumn+` = $1 ORDER BY version`, entityId)
+	if err != nil {
+		t.Fatalf("select %s: %v", table, err)
+	}
+	defer rows.Close()
+
+	var versions []int
+	for rows.Next() {
+		var version int
+		if err = rows.Scan(&version); err != nil {
+			t.Fatalf("scan %s: %v", table, err)
+		}
+		versions = append(versions, version)
+	}
+	return versions
+}
+
+func TestHistoryVersionMigrationArchivesCurrentVersion(t *testing.T) {
+	const (
+		employeeId     = "0b6f7a3c-1d52-4f1e-9a57-3c1f2b8e4d10"
+		organizationId = "7c2e9d41-58a3-4b6f-8e0d-92f1a6c3b7e5"
+		tenderId       = "3a8f1c27-6e4b-4d09-b2f5-81c7e9a04d36"
+		bidId          = "c5d2e8f0-9b13-4a67-8c4e-2f6a1d7b3e95"
+	)
+	ctx := context.Background()
+	pool, migration := newTestDB(t)
+	if err := migration.Migrate(3); err != nil {
+		t.Fatalf("migrate to 3: %v", err)
+	}
+
+	// До миграции 000004 редактирование сохраняло в историю и текущую версию: у тендера и предложения
+	// версии 2 в истории лежат версии 1 и 2, а версия 1 тендера ещё и повторяется.
+	seedTestDB(t, pool,
+		`INSERT INTO employee (id, username) VALUES ('`+employeeId+`', 'user1')`,
+		`INSERT INTO organization (id, name, type) VALUES ('`+organizationId+`', 'Org', 'LLC')`,
+		`INSERT INTO organization_responsible (organization_id, user_id) VALUES ('`+organizationId+`', '`+employeeId+`')`,
+		`INSERT INTO tender (id, name, description, service_type, status, organization_id, version, creator_username)
+		 VALUES ('`+tenderId+`', 'Tender', 'd', 'Delivery', 'Created', '`+organizationId+`', 2, 'user1')`,
+		`INSERT INTO tender_history (id, name, description, service_type, status, organization_id, version, creator_username)
+		 SELECT id, name, description, service_type, status, organization_id, v, creator_username
+		 FROM tender, unnest(ARRAY[1, 1, 2]) AS v`,
+		`INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, version)
+		 VALUES ('`+bidId+`', 'Bid', 'd', 'Created', '`+tenderId+`', 'User', '`+employeeId+`', 2)`,
+		`INSERT INTO bid_history (bid_id, name, description, status, author_type, author_id, version)
+		 SELECT id, name, description, status, author_type, author_id, v FROM bid, unnest(ARRAY[1, 2]) AS v`,
+	)
+
+	if err := migration.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	if got := historyVersions(t, pool, "tender_history", "id", tenderId); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("tender history = %v, want [1]", got)
+	}
+	if got := historyVersions(t, pool, "tender_history_duplicate", "id", tenderId); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("archived tender history = %v, want [1 2]", got)
+	}
+	if got := historyVersions(t, pool, "bid_history", "bid_id", bidId); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("bid history = %v, want [1]", got)
+	}
+	if got := historyVersions(t, pool, "bid_history_duplicate", "bid_id", bidId); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("archived bid history = %v, want [2]", got)
+	}
+
+	// Следующее изменение сохраняет текущую версию в историю без нарушения уникальности.
+	uow := db.NewUnitOfWork(pool)
+	tenders := NewPostgresTenderRepository(pool)
+	bids := NewPostgresBidRepository(pool)
+	err := uow.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := tenders.UpdateTenderStatus(ctx, tenderId, string(models.PublishedTender), 0)
+		if err != nil {
+			return err
+		}
+		if tender.Version != 3 {
+			t.Errorf("tender version = %d, want 3", tender.Version)
+		}
+		bid, err := bids.UpdateBidStatus(ctx, bidId, string(models.PublishedBid), 0)
+		if err != nil {
+			return err
+		}
+		if bid.Version != 3 {
+			t.Errorf("bid version = %d, want 3", bid.Version)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+	if got := historyVersions(t, pool, "tender_history", "id", tenderId); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("tender history after update = %v, want [1 2]", got)
+	}
+	if got := historyVersions(t, pool, "bid_history", "bid_id", bidId); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("bid history after update = %v, want [1 2]", got)
+	}
+}
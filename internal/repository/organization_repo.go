@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// organizationColumns - список столбцов таблицы organization в порядке, ожидаемом scanOrganization.
+const organizationColumns = `id, name, COALESCE(description, ''), COALESCE(type::text, ''), created_at, updated_at`
+
+// OrganizationRepository - интерфейс для работы с организациями и их ответственными.
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, organizationReq models.OrganizationRequest) (*models.OrganizationInfo, error)
+	GetOrganization(ctx context.Context, organizationId string) (*models.OrganizationInfo, error)
+	GetOrganizations(ctx context.Context, limit, offset int) ([]models.OrganizationInfo, error)
+	UpdateOrganization(ctx context.Context, organizationId string, updateFields map[string]interface{}) (*models.OrganizationInfo, error)
+	DeleteOrganization(ctx context.Context, organizationId string) error
+	AddResponsible(ctx context.Context, organizationId, userId string) error
+	RemoveResponsible(ctx context.Context, organizationId, userId string) error
+	GetResponsibles(ctx context.Context, organizationId string) ([]models.Employee, error)
+}
+
+// PostgresOrganizationRepository - реализация OrganizationRepository для базы данных.
+type PostgresOrganizationRepository struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresOrganizationRepository создаёт новый экземпляр PostgresOrganizationRepository.
+func NewPostgresOrganizationRepository(db *pgxpool.Pool) *PostgresOrganizationRepository {
+	return &PostgresOrganizationRepository{DB: db}
+}
+
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresOrganizationRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// scanOrganization считывает организацию из строки результата, выбранной по organizationColumns.
+func scanOrganization(row pgx.Row) (*models.OrganizationInfo, error) {
+	var organization models.OrganizationInfo
+	err := row.Scan(
+		&organization.ID,
+		&organization.Name,
+		&organization.Description,
+		&organization.Type,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// CreateOrganization создаёт новую организацию.
+func (r *PostgresOrganizationRepository) CreateOrganization(ctx context.Context, organizationReq models.OrganizationRequest) (*models.OrganizationInfo, error) {
+	now := time.Now().UTC()
+	newOrganization := models.OrganizationInfo{
+		ID:          uuid.New().String(),
+		Name:        organizationReq.Name,
+		Description: organizationReq.Description,
+		Type:        organizationReq.Type,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	insertQuery := `INSERT INTO organization (id, name, description, type, created_at, updated_at)
+	                VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.conn(ctx).Exec(
+		ctx,
+		insertQuery,
+		newOrganization.ID,
+		newOrganization.Name,
+		newOrganization.Description,
+		newOrganization.Type,
+		newOrganization.CreatedAt,
+		newOrganization.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert organization: %w", err)
+	}
+	return &newOrganization, nil
+}
+
+// GetOrganization возвращает организацию по id.
+func (r *PostgresOrganizationRepository) GetOrganization(ctx context.Context, organizationId string) (*models.OrganizationInfo, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organization WHERE id = $1`
+	return scanOrganization(r.conn(ctx).QueryRow(ctx, query, organizationId))
+}
+
+// GetOrganizations возвращает список организаций.
+func (r *PostgresOrganizationRepository) GetOrganizations(ctx context.Context, limit, offset int) ([]models.OrganizationInfo, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organization ORDER BY name LIMIT $1 OFFSET $2`
+	rows, err := r.conn(ctx).Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var organizations []models.OrganizationInfo
+	for rows.Next() {
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, *organization)
+	}
+	return organizations, rows.Err()
+}
+
+// UpdateOrganization меняет данные организации.
+// Поддерживаются поля name, description и type.
+func (r *PostgresOrganizationRepository) UpdateOrganization(ctx context.Context, organizationId string, updateFields map[string]interface{}) (*models.OrganizationInfo, error) {
+	var updates []string
+	var args []interface{}
+	argIndex := 1
+	for _, field := range []string{"name", "description", "type"} {
+		if value, ok := updateFields[field]; ok {
+			updates = append(updates, fmt.Sprintf("%s = $%d", field, argIndex))
+			args = append(args, value)
+			argIndex++
+		}
+	}
+	updates = append(updates, "updated_at = CURRENT_TIMESTAMP")
+
+	updateQuery := fmt.Sprintf("UPDATE organization SET %s WHERE id = $%d RETURNING %s", strings.Join(updates, ", "), argIndex, organizationColumns)
+	args = append(args, organizationId)
+
+	return scanOrganization(r.conn(ctx).QueryRow(ctx, updateQuery, args...))
+}
+
+// DeleteOrganization удаляет организацию вместе с её ответственными и тендерами.
+func (r *PostgresOrganizationRepository) DeleteOrganization(ctx context.Context, organizationId string) error {
+	tag, err := r.conn(ctx).Exec(ctx, `DELETE FROM organization WHERE id = $1`, organizationId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// AddResponsible назначает пользователя ответственным за организацию. Пользователь может быть ответственным
+// только в одной организации: по ней определяется организация его предложений. Строки организации и
+// пользователя блокируются до конца транзакции, чтобы параллельные назначения не нарушили это правило,
+// поэтому метод следует вызывать внутри транзакции. Если пользователь уже ответственный за какую-либо
+// организацию, возвращается ошибка 409.
+func (r *PostgresOrganizationRepository) AddResponsible(ctx context.Context, organizationId, userId string) error {
+	if _, err := r.conn(ctx).Exec(ctx, `SELECT 1 FROM organization WHERE id = $1 FOR UPDATE`, organizationId); err != nil {
+		return err
+	}
+	if _, err := r.conn(ctx).Exec(ctx, `SELECT 1 FROM employee WHERE id = $1 FOR UPDATE`, userId); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO organization_responsible (id, organization_id, user_id)
+		SELECT $1::uuid, $2::uuid, $3::uuid
+		WHERE NOT EXISTS (SELECT 1 FROM organization_responsible WHERE user_id = $3::uuid)`
+	tag, err := r.conn(ctx).Exec(ctx, insertQuery, uuid.New().String(), organizationId, userId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.NewErrorResponse(http.StatusConflict, "user is already responsible for an organization")
+	}
+	return nil
+}
+
+// RemoveResponsible снимает с пользователя ответственность за организацию. Строка организации блокируется
+// до конца транзакции, чтобы параллельные снятия видели результат друг друга при подсчёте оставшихся
+// ответственных, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresOrganizationRepository) RemoveResponsible(ctx context.Context, organizationId, userId string) error {
+	if _, err := r.conn(ctx).Exec(ctx, `SELECT 1 FROM organization WHERE id = $1 FOR UPDATE`, organizationId); err != nil {
+		return err
+	}
+
+	deleteQuery := `DELETE FROM organization_responsible WHERE organization_id = $1 AND user_id = $2`
+	tag, err := r.conn(ctx).Exec(ctx, deleteQuery, organizationId, userId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetResponsibles возвращает список ответственных за организацию.
+func (r *PostgresOrganizationRepository) GetResponsibles(ctx context.Context, organizationId string) ([]models.Employee, error) {
+	query := `SELECT e.id, e.username, COALESCE(e.first_name, ''), COALESCE(e.last_name, ''), e.created_at, e.updated_at
+	          FROM organization_responsible orr
+	          JOIN employee e ON orr.user_id = e.id
+	          WHERE orr.organization_id = $1
+	          ORDER BY e.username`
+	rows, err := r.conn(ctx).Query(ctx, query, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	return collectEmployees(rows)
+}
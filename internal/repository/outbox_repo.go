@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/events"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxRepository - интерфейс для работы с таблицей outbox.
+type OutboxRepository interface {
+	SaveEvent(ctx context.Context, event events.Event) error
+	LockPending(ctx context.Context, limit int) ([]models.OutboxMessage, error)
+	MarkPublished(ctx context.Context, ids []int64, publishedAt time.Time) error
+	RecordFailure(ctx context.Context, id int64, lastError string) error
+	DeadLetter(ctx context.Context, message models.OutboxMessage, lastError string) error
+}
+
+// PostgresOutboxRepository - реализация OutboxRepository для базы данных.
+type PostgresOutboxRepository struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresOutboxRepository создаёт новый экземпляр PostgresOutboxRepository.
+func NewPostgresOutboxRepository(db *pgxpool.Pool) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{DB: db}
+}
+
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresOutboxRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// SaveEvent сохраняет событие в outbox. Ключом идемпотентности служит идентификатор события,
+// повторное сохранение того же события игнорируется. Метод следует вызывать в той же транзакции,
+// что и само изменение.
+func (r *PostgresOutboxRepository) SaveEvent(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO outbox (idempotency_key, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (idempotency_key) DO NOTHING`
+	_, err = r.conn(ctx).Exec(ctx, query, event.ID, string(event.Type), payload, event.OccurredAt)
+	return err
+}
+
+// LockPending возвращает не более limit неотправленных сообщений в порядке идентификаторов и блокирует их
+// до конца транзакции, поэтому метод следует вызывать внутри транзакции. Идентификаторы выдаются при
+// вставке, а транзакции фиксируются в своём порядке, поэтому порядок идентификаторов может отличаться
+// от порядка фиксации изменений.
+func (r *PostgresOutboxRepository) LockPending(ctx context.Context, limit int) ([]models.OutboxMessage, error) {
+	query := `
+		SELECT id, idempotency_key, event_type, payload, attempts, created_at
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE`
+	rows, err := r.conn(ctx).Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []models.OutboxMessage
+	for rows.Next() {
+		var message models.OutboxMessage
+		err = rows.Scan(&message.ID, &message.IdempotencyKey, &message.EventType, &message.Payload, &message.Attempts, &message.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// MarkPublished отмечает сообщения отправленными.
+func (r *PostgresOutboxRepository) MarkPublished(ctx context.Context, ids []int64, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := `UPDATE outbox SET published_at = $2 WHERE id = ANY($1)`
+	_, err := r.conn(ctx).Exec(ctx, query, ids, publishedAt)
+	return err
+}
+
+// RecordFailure увеличивает счётчик неудачных попыток отправки сообщения и сохраняет ошибку последней попытки.
+func (r *PostgresOutboxRepository) RecordFailure(ctx context.Context, id int64, lastError string) error {
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`
+	_, err := r.conn(ctx).Exec(ctx, query, id, lastError)
+	return err
+}
+
+// DeadLetter переносит сообщение из outbox в таблицу недоставленных сообщений вместе с числом попыток
+// и ошибкой последней из них. Метод следует вызывать внутри транзакции.
+func (r *PostgresOutboxRepository) DeadLetter(ctx context.Context, message models.OutboxMessage, lastError string) error {
+	insertQuery := `
+		INSERT INTO outbox_dead_letter (id, idempotency_key, event_type, payload, attempts, last_error, created_at, failed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.conn(ctx).Exec(ctx, insertQuery, message.ID, message.IdempotencyKey, message.EventType, message.Payload,
+		message.Attempts, lastError, message.CreatedAt, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	_, err = r.conn(ctx).Exec(ctx, `DELETE FROM outbox WHERE id = $1`, message.ID)
+	return err
+}
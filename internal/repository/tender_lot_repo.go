@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+)
+
+// tenderLotColumns - список столбцов таблицы tender_lot в порядке, ожидаемом scanTenderLot.
+const tenderLotColumns = `id, tender_id, name, description, quantity, unit, max_budget, currency, created_at`
+
+// tenderLotsSnapshot возвращает подзапрос, собирающий лоты тендера tenderIdExpr в JSON-массив для снимка версии.
+func tenderLotsSnapshot(tenderIdExpr string) string {
+	return `(SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'id', l.id, 'tenderId', l.tender_id, 'name', l.name, 'description', l.description,
+			'quantity', l.quantity, 'unit', l.unit, 'maxBudget', l.max_budget, 'currency', l.currency,
+			'createdAt', l.created_at AT TIME ZONE 'UTC') ORDER BY l.created_at, l.id), '[]'::jsonb)
+		FROM tender_lot l WHERE l.tender_id = ` + tenderIdExpr + `)`
+}
+
+// scanTenderLot считывает лот из строки результата, выбранной по tenderLotColumns.
+func scanTenderLot(row pgx.Row) (*models.TenderLot, error) {
+	var lot models.TenderLot
+	err := row.Scan(
+		&lot.ID,
+		&lot.TenderID,
+		&lot.Name,
+		&lot.Description,
+		&lot.Quantity,
+		&lot.Unit,
+		&lot.MaxBudget,
+		&lot.Currency,
+		&lot.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+// GetTenderLots возвращает лоты тендера в порядке создания.
+func (r *PostgresTenderRepository) GetTenderLots(ctx context.Context, tenderId string) ([]models.TenderLot, error) {
+	query := `SELECT ` + tenderLotColumns + ` FROM tender_lot WHERE tender_id = $1 ORDER BY created_at, id`
+	rows, err := r.conn(ctx).Query(ctx, query, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots := []models.TenderLot{}
+	for rows.Next() {
+		lot, err := scanTenderLot(rows)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, *lot)
+	}
+	return lots, rows.Err()
+}
+
+// GetTenderLot возвращает лот тендера.
+func (r *PostgresTenderRepository) GetTenderLot(ctx context.Context, tenderId, lotId string) (*models.TenderLot, error) {
+	query := `SELECT ` + tenderLotColumns + ` FROM tender_lot WHERE tender_id = $1 AND id = $2`
+	return scanTenderLot(r.conn(ctx).QueryRow(ctx, query, tenderId, lotId))
+}
+
+// CreateTenderLot добавляет лот в тендер и создаёт новую версию тендера.
+// Тендер блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) CreateTenderLot(ctx context.Context, tenderId string, expectedVersion int, lotReq models.TenderLotRequest) (*models.TenderLot, *models.Tender, error) {
+	newLot := models.TenderLot{
+		ID:          uuid.New().String(),
+		TenderID:    tenderId,
+		Name:        lotReq.Name,
+		Description: lotReq.Description,
+		Quantity:    lotReq.Quantity,
+		Unit:        lotReq.Unit,
+		MaxBudget:   lotReq.MaxBudget,
+		Currency:    lotReq.Currency,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := models.ValidateTenderLot(&newLot); err != nil {
+		return nil, nil, err
+	}
+
+	if err := r.beginTenderChange(ctx, tenderId, expectedVersion); err != nil {
+		return nil, nil, err
+	}
+	if err := r.insertTenderLot(ctx, &newLot); err != nil {
+		return nil, nil, err
+	}
+
+	updatedTender, err := r.commitTenderChange(ctx, tenderId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &newLot, updatedTender, nil
+}
+
+// EditTenderLot меняет поля лота и создаёт новую версию тендера.
+// Тендер блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) EditTenderLot(ctx context.Context, tenderId, lotId string, expectedVersion int, updateFields map[string]interface{}) (*models.TenderLot, *models.Tender, error) {
+	if err := r.beginTenderChange(ctx, tenderId, expectedVersion); err != nil {
+		return nil, nil, err
+	}
+	lot, err := r.GetTenderLot(ctx, tenderId, lotId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updates []string
+	args := []interface{}{lotId}
+	argIndex := 2
+
+	if name, ok := updateFields["name"].(string); ok && name != "" {
+		lot.Name = name
+		updates = append(updates, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, name)
+		argIndex++
+	}
+
+	if description, ok := updateFields["description"].(string); ok {
+		lot.Description = description
+		updates = append(updates, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, description)
+		argIndex++
+	}
+
+	if unit, ok := updateFields["unit"].(string); ok && unit != "" {
+		lot.Unit = unit
+		updates = append(updates, fmt.Sprintf("unit = $%d", argIndex))
+		args = append(args, unit)
+		argIndex++
+	}
+
+	if value, ok := updateFields["quantity"]; ok {
+		quantity, ok := value.(float64)
+		if !ok {
+			return nil, nil, models.NewErrorResponse(http.StatusBadRequest, "invalid quantity, must be a positive number")
+		}
+		lot.Quantity = quantity
+		updates = append(updates, fmt.Sprintf("quantity = $%d", argIndex))
+		args = append(args, quantity)
+		argIndex++
+	}
+
+	if value, ok := updateFields["maxBudget"]; ok {
+		if lot.MaxBudget, err = parseOptionalNumber("maxBudget", value); err != nil {
+			return nil, nil, err
+		}
+		updates = append(updates, fmt.Sprintf("max_budget = $%d", argIndex))
+		args = append(args, lot.MaxBudget)
+		argIndex++
+	}
+
+	if value, ok := updateFields["currency"]; ok {
+		if lot.Currency, err = parseOptionalString("currency", value); err != nil {
+			return nil, nil, err
+		}
+		updates = append(updates, fmt.Sprintf("currency = $%d", argIndex))
+		args = append(args, lot.Currency)
+		argIndex++
+	}
+
+	if len(updates) == 0 {
+		return nil, nil, models.NewErrorResponse(http.StatusBadRequest, "no valid fields to update")
+	}
+	if err = models.ValidateTenderLot(lot); err != nil {
+		return nil, nil, err
+	}
+
+	updateQuery := fmt.Sprintf("UPDATE tender_lot SET %s WHERE id = $1 RETURNING %s", strings.Join(updates, ", "), tenderLotColumns)
+	updatedLot, err := scanTenderLot(r.conn(ctx).QueryRow(ctx, updateQuery, args...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updatedTender, err := r.commitTenderChange(ctx, tenderId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return updatedLot, updatedTender, nil
+}
+
+// DeleteTenderLot удаляет лот тендера и создаёт новую версию тендера.
+// Лот, по которому уже поданы цены в предложениях, удалить нельзя.
+// Тендер блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) DeleteTenderLot(ctx context.Context, tenderId, lotId string, expectedVersion int) (*models.Tender, error) {
+	if err := r.beginTenderChange(ctx, tenderId, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	var hasBids bool
+	bidsQuery := `SELECT EXISTS(
+		SELECT 1 FROM bid
+		WHERE tender_id = $1 AND lot_prices @> jsonb_build_array(jsonb_build_object('lotId', $2::text)))`
+	if err := r.conn(ctx).QueryRow(ctx, bidsQuery, tenderId, lotId).Scan(&hasBids); err != nil {
+		return nil, err
+	}
+	if hasBids {
+		return nil, models.NewErrorResponse(http.StatusConflict, "lot has bids and cannot be deleted")
+	}
+
+	tag, err := r.conn(ctx).Exec(ctx, `DELETE FROM tender_lot WHERE tender_id = $1 AND id = $2`, tenderId, lotId)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	return r.commitTenderChange(ctx, tenderId)
+}
+
+// beginTenderChange блокирует тендер перед изменением лотов или вложений, сверяет ожидаемую версию
+// и сохраняет текущую версию тендера вместе с лотами и вложениями в истории.
+func (r *PostgresTenderRepository) beginTenderChange(ctx context.Context, tenderId string, expectedVersion int) error {
+	currentTender, err := r.lockTender(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+	if err = checkExpectedVersion(expectedVersion, int(currentTender.Version)); err != nil {
+		return err
+	}
+	return r.saveTenderHistory(ctx, currentTender)
+}
+
+// commitTenderChange увеличивает версию тендера после изменения лотов или вложений и возвращает новую версию
+// вместе с лотами и вложениями.
+func (r *PostgresTenderRepository) commitTenderChange(ctx context.Context, tenderId string) (*models.Tender, error) {
+	updateQuery := `UPDATE tender SET version = version + 1 WHERE id = $1
+	                RETURNING ` + tenderColumns + `, ` + tenderSnapshotColumns("tender.id")
+	return scanTenderVersion(r.conn(ctx).QueryRow(ctx, updateQuery, tenderId))
+}
+
+// insertTenderLot сохраняет лот в tender_lot.
+func (r *PostgresTenderRepository) insertTenderLot(ctx context.Context, lot *models.TenderLot) error {
+	insertQuery := `INSERT INTO tender_lot (` + tenderLotColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.conn(ctx).Exec(
+		ctx,
+		insertQuery,
+		lot.ID,
+		lot.TenderID,
+		lot.Name,
+		lot.Description,
+		lot.Quantity,
+		lot.Unit,
+		lot.MaxBudget,
+		lot.Currency,
+		lot.CreatedAt)
+	return err
+}
+
+// replaceTenderLots заменяет лоты тендера набором из снимка версии. Как и при удалении лота,
+// нельзя убрать лоты, по которым уже поданы цены в предложениях: в этом случае возвращается ошибка 409.
+func (r *PostgresTenderRepository) replaceTenderLots(ctx context.Context, tenderId string, lots []models.TenderLot) error {
+	keptLotIds := make([]string, len(lots))
+	for i := range lots {
+		keptLotIds[i] = lots[i].ID
+	}
+
+	var hasBids bool
+	bidsQuery := `SELECT EXISTS(
+		SELECT 1 FROM tender_lot l
+		JOIN bid ON bid.tender_id = l.tender_id
+		WHERE l.tender_id = $1 AND NOT (l.id::text = ANY($2))
+		  AND bid.lot_prices @> jsonb_build_array(jsonb_build_object('lotId', l.id::text)))`
+	if err := r.conn(ctx).QueryRow(ctx, bidsQuery, tenderId, pq.Array(keptLotIds)).Scan(&hasBids); err != nil {
+		return err
+	}
+	if hasBids {
+		return models.NewErrorResponse(http.StatusConflict, "rollback would remove lots that have bids")
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, `DELETE FROM tender_lot WHERE tender_id = $1`, tenderId); err != nil {
+		return err
+	}
+	for i := range lots {
+		lots[i].TenderID = tenderId
+		if err := r.insertTenderLot(ctx, &lots[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// tenderQuestionColumns возвращает список столбцов таблицы tender_question в порядке, ожидаемом scanTenderQuestion.
+// viewerParam - параметр запроса с идентификатором пользователя, для которого вычисляется признак askedByMe.
+func tenderQuestionColumns(viewerParam string) string {
+	return `q.id, q.tender_id, q.question, q.answer, q.asker_id::text = ` + viewerParam + `, q.created_at, q.answered_at`
+}
+
+// tenderQuestionVisibility - условие видимости вопроса пользователю $2: вопросы с ответом видны всем,
+// кто видит тендер, вопросы без ответа - автору и ответственным за организацию тендера.
+const tenderQuestionVisibility = `(
+		q.answer IS NOT NULL
+		OR q.asker_id::text = $2
+		OR EXISTS (
+			SELECT 1 FROM tender t
+			JOIN organization_responsible o ON o.organization_id = t.organization_id
+			WHERE t.id = q.tender_id AND o.user_id::text = $2)
+	)`
+
+// tenderQuestionOrder - порядок списка вопросов: от ранних к поздним, при равном времени - по идентификатору.
+var tenderQuestionOrder = keysetOrder[models.TenderQuestion]{
+	{column: "q.created_at", cast: "timestamp", value: func(q *models.TenderQuestion) any { return keysetTime(q.CreatedAt) }},
+	{column: "q.id", cast: "uuid", value: func(q *models.TenderQuestion) any { return q.ID }},
+}
+
+// TenderQuestionRepository - интерфейс для работы с вопросами и ответами по тендерам.
+type TenderQuestionRepository interface {
+	GetQuestions(ctx context.Context, tenderId, viewerId string, page models.Page) (models.Paged[models.TenderQuestion], error)
+	CreateQuestion(ctx context.Context, tenderId, askerId string, questionReq models.TenderQuestionRequest) (*models.TenderQuestion, error)
+	AnswerQuestion(ctx context.Context, tenderId, questionId, userId string, answerReq models.TenderAnswerRequest) (*models.TenderQuestion, error)
+}
+
+// PostgresTenderQuestionRepository - реализация TenderQuestionRepository для базы данных.
+type PostgresTenderQuestionRepository struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresTenderQuestionRepository создаёт новый экземпляр PostgresTenderQuestionRepository.
+func NewPostgresTenderQuestionRepository(db *pgxpool.Pool) *PostgresTenderQuestionRepository {
+	return &PostgresTenderQuestionRepository{DB: db}
+}
+
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresTenderQuestionRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// scanTenderQuestion считывает вопрос из строки результата, выбранной по tenderQuestionColumns.
+func scanTenderQuestion(row pgx.Row) (*models.TenderQuestion, error) {
+	var question models.TenderQuestion
+	err := row.Scan(
+		&question.ID,
+		&question.TenderID,
+		&question.Question,
+		&question.Answer,
+		&question.AskedByMe,
+		&question.CreatedAt,
+		&question.AnsweredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// GetQuestions возвращает страницу списка вопросов по тендеру, видимых пользователю viewerId.
+// Анонимному пользователю (пустой viewerId) видны только вопросы с ответом.
+func (r *PostgresTenderQuestionRepository) GetQuestions(ctx context.Context, tenderId, viewerId string, page models.Page) (models.Paged[models.TenderQuestion], error) {
+	filters := []string{"q.tender_id = $1", tenderQuestionVisibility}
+	tail, args, err := tenderQuestionOrder.page(filters, []interface{}{tenderId, viewerId}, page)
+	if err != nil {
+		return models.Paged[models.TenderQuestion]{}, err
+	}
+	rows, err := r.conn(ctx).Query(ctx, `SELECT `+tenderQuestionColumns("$2")+` FROM tender_question q`+tail, args...)
+	if err != nil {
+		return models.Paged[models.TenderQuestion]{}, err
+	}
+	defer rows.Close()
+
+	var questions []models.TenderQuestion
+	for rows.Next() {
+		question, err := scanTenderQuestion(rows)
+		if err != nil {
+			return models.Paged[models.TenderQuestion]{}, err
+		}
+		questions = append(questions, *question)
+	}
+	if err = rows.Err(); err != nil {
+		return models.Paged[models.TenderQuestion]{}, err
+	}
+	return tenderQuestionOrder.collect(questions, page.Limit), nil
+}
+
+// CreateQuestion сохраняет вопрос пользователя askerId по тендеру.
+func (r *PostgresTenderQuestionRepository) CreateQuestion(ctx context.Context, tenderId, askerId string, questionReq models.TenderQuestionRequest) (*models.TenderQuestion, error) {
+	query := `
+		INSERT INTO tender_question AS q (id, tender_id, asker_id, question, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + tenderQuestionColumns("$3::text")
+	return scanTenderQuestion(r.conn(ctx).QueryRow(ctx, query,
+		uuid.New().String(), tenderId, askerId, questionReq.Question, time.Now().UTC()))
+}
+
+// AnswerQuestion сохраняет ответ пользователя userId на вопрос по тендеру. Ответ даётся один раз:
+// на вопрос с ответом возвращается ошибка 409, на отсутствующий вопрос - pgx.ErrNoRows.
+func (r *PostgresTenderQuestionRepository) AnswerQuestion(ctx context.Context, tenderId, questionId, userId string, answerReq models.TenderAnswerRequest) (*models.TenderQuestion, error) {
+	query := `
+		UPDATE tender_question AS q SET answer = $3, answered_by = $4, answered_at = $5
+		WHERE q.tender_id = $1 AND q.id = $2 AND q.answer IS NULL
+		RETURNING ` + tenderQuestionColumns("$4::text")
+	question, err := scanTenderQuestion(r.conn(ctx).QueryRow(ctx, query,
+		tenderId, questionId, answerReq.Answer, userId, time.Now().UTC()))
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return question, err
+	}
+
+	var exists bool
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM tender_question WHERE tender_id = $1 AND id = $2)`
+	if err = r.conn(ctx).QueryRow(ctx, existsQuery, tenderId, questionId).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, models.NewErrorResponse(http.StatusConflict, "question has already been answered")
+	}
+	return nil, pgx.ErrNoRows
+}
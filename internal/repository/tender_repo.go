@@ -4,26 +4,48 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/senyabanana/tender-service/internal/db"
 	"github.com/senyabanana/tender-service/internal/models"
-	"github.com/senyabanana/tender-service/internal/utils"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lib/pq"
 )
 
+// tenderColumns - список столбцов таблицы tender в порядке, ожидаемом scanTender.
+const tenderColumns = `id, name, description, service_type, status, organization_id, version, created_at, creator_username,
+	submission_deadline, decision_deadline, sealed, bids_opening_at, bids_opened_at`
+
 // TenderRepository - интерфейс для работы с тендерами.
 type TenderRepository interface {
-	GetTenders(ctx context.Context, limit, offset int, serviceTypes []string) ([]models.Tender, error)
+	GetTenders(ctx context.Context, page models.Page, filter models.TenderFilter, viewerId string) (models.Paged[models.Tender], error)
+	SearchTenders(ctx context.Context, search models.TenderSearch, viewerId string, limit, offset int) (*models.TenderSearchResult, error)
 	CreateTender(ctx context.Context, tenderReq models.TenderRequest) (*models.Tender, error)
-	GetUserTender(ctx context.Context, limit, offset int, username string) ([]models.Tender, error)
-	GetTenderStatus(ctx context.Context, tenderId, username string) (models.TenderStatus, error)
-	UpdateTenderStatus(ctx context.Context, tenderId, status string) (*models.Tender, error)
-	EditTender(ctx context.Context, tenderId string, updateFields map[string]interface{}) (*models.Tender, error)
-	RollbackTender(ctx context.Context, tenderId string, version int) (*models.Tender, error)
+	GetUserTender(ctx context.Context, page models.Page, filter models.TenderFilter, username string) (models.Paged[models.Tender], error)
+	GetTenderStatus(ctx context.Context, tenderId string) (models.TenderStatus, error)
+	UpdateTenderStatus(ctx context.Context, tenderId, status string, expectedVersion int) (*models.Tender, error)
+	CloseTender(ctx context.Context, tenderId string) (*models.Tender, error)
+	ShareLockTender(ctx context.Context, tenderId string) (*models.Tender, error)
+	EditTender(ctx context.Context, tenderId string, expectedVersion int, updateFields map[string]interface{}) (*models.Tender, error)
+	RollbackTender(ctx context.Context, tenderId string, version, expectedVersion int) (*models.Tender, error)
+	GetTenderVersions(ctx context.Context, tenderId string) ([]models.Tender, error)
+	GetTenderVersion(ctx context.Context, tenderId string, version int) (*models.Tender, error)
+	CloseExpiredTenders(ctx context.Context, now time.Time, limit int) ([]models.Tender, error)
+	OpenSealedBids(ctx context.Context, now time.Time, limit int) ([]models.Tender, error)
+	GetTenderLots(ctx context.Context, tenderId string) ([]models.TenderLot, error)
+	GetTenderLot(ctx context.Context, tenderId, lotId string) (*models.TenderLot, error)
+	CreateTenderLot(ctx context.Context, tenderId string, expectedVersion int, lotReq models.TenderLotRequest) (*models.TenderLot, *models.Tender, error)
+	EditTenderLot(ctx context.Context, tenderId, lotId string, expectedVersion int, updateFields map[string]interface{}) (*models.TenderLot, *models.Tender, error)
+	DeleteTenderLot(ctx context.Context, tenderId, lotId string, expectedVersion int) (*models.Tender, error)
+	GetTenderAttachments(ctx context.Context, tenderId string) ([]models.Attachment, error)
+	GetTenderAttachment(ctx context.Context, tenderId, attachmentId string) (*models.Attachment, error)
+	CreateTenderAttachment(ctx context.Context, tenderId string, expectedVersion int, attachment models.Attachment) (*models.Tender, error)
+	DeleteTenderAttachment(ctx context.Context, tenderId, attachmentId string, expectedVersion int) (*models.Tender, error)
 }
 
 // PostgresTenderRepository - реализация TenderRepository для базы данных.
@@ -36,50 +58,186 @@ func NewPostgresTenderRepository(db *pgxpool.Pool) *PostgresTenderRepository {
 	return &PostgresTenderRepository{DB: db}
 }
 
-// GetTenders возвращает список тендеров.
-func (r *PostgresTenderRepository) GetTenders(ctx context.Context, limit, offset int, serviceTypes []string) ([]models.Tender, error) {
-	query := `SELECT id, name, description, status, service_type, organization_id, version, created_at, creator_username FROM tender` // TODO: не забыть убрать
-	var filters []string
-	var args []interface{}
-	argIndex := 1
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresTenderRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
 
-	if len(serviceTypes) > 0 {
-		filters = append(filters, fmt.Sprintf("service_type = ANY($%d)", argIndex))
-		args = append(args, pq.Array(serviceTypes))
-		argIndex++
+// tenderScanTargets возвращает поля тендера в порядке tenderColumns.
+func tenderScanTargets(tender *models.Tender) []interface{} {
+	return []interface{}{
+		&tender.ID,
+		&tender.Name,
+		&tender.Description,
+		&tender.ServiceType,
+		&tender.Status,
+		&tender.OrganizationID,
+		&tender.Version,
+		&tender.CreatedAt,
+		&tender.CreatorUsername,
+		&tender.SubmissionDeadline,
+		&tender.DecisionDeadline,
+		&tender.Sealed,
+		&tender.BidsOpeningAt,
+		&tender.BidsOpenedAt,
 	}
+}
 
-	if len(filters) > 0 {
-		query += " WHERE " + strings.Join(filters, " AND ")
+// scanTender считывает тендер из строки результата, выбранной по tenderColumns.
+func scanTender(row pgx.Row) (*models.Tender, error) {
+	var tender models.Tender
+	if err := row.Scan(tenderScanTargets(&tender)...); err != nil {
+		return nil, err
 	}
+	return &tender, nil
+}
 
-	query += fmt.Sprintf(" ORDER BY name LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, limit, offset)
+// tenderSnapshotColumns возвращает подзапросы, собирающие лоты и вложения тендера tenderIdExpr для снимка версии,
+// в порядке столбцов lots и attachments таблицы tender_history.
+func tenderSnapshotColumns(tenderIdExpr string) string {
+	return tenderLotsSnapshot(tenderIdExpr) + `, ` + tenderAttachments.snapshot(tenderIdExpr)
+}
 
-	rows, err := r.DB.Query(ctx, query, args...)
-	if err != nil {
+// scanTenderVersion считывает снимок версии тендера, выбранный по tenderColumns и столбцам lots и attachments.
+func scanTenderVersion(row pgx.Row) (*models.Tender, error) {
+	var tender models.Tender
+	if err := row.Scan(append(tenderScanTargets(&tender), &tender.Lots, &tender.Attachments)...); err != nil {
 		return nil, err
 	}
+	return &tender, nil
+}
+
+// collectTenders считывает все тендеры из результата запроса.
+func collectTenders(rows pgx.Rows) ([]models.Tender, error) {
 	defer rows.Close()
 
 	var tenders []models.Tender
 	for rows.Next() {
-		var tender models.Tender
-		if err := rows.Scan(
-			&tender.ID,
-			&tender.Name,
-			&tender.Description,
-			&tender.ServiceType,
-			&tender.Status,
-			&tender.OrganizationID,
-			&tender.Version,
-			&tender.CreatedAt,
-			&tender.CreatorUsername); err != nil {
+		tender, err := scanTender(rows)
+		if err != nil {
 			return nil, err
 		}
-		tenders = append(tenders, tender)
+		tenders = append(tenders, *tender)
+	}
+	return tenders, rows.Err()
+}
+
+// lockTender выбирает тендер с блокировкой строки до конца текущей транзакции.
+func (r *PostgresTenderRepository) lockTender(ctx context.Context, tenderId string) (*models.Tender, error) {
+	query := `SELECT ` + tenderColumns + ` FROM tender WHERE id = $1 FOR UPDATE`
+	return scanTender(r.conn(ctx).QueryRow(ctx, query, tenderId))
+}
+
+// ShareLockTender выбирает тендер с разделяемой блокировкой строки до конца текущей транзакции:
+// пока она держится, тендер нельзя изменить или закрыть, но другие транзакции тоже могут её взять.
+func (r *PostgresTenderRepository) ShareLockTender(ctx context.Context, tenderId string) (*models.Tender, error) {
+	query := `SELECT ` + tenderColumns + ` FROM tender WHERE id = $1 FOR SHARE`
+	return scanTender(r.conn(ctx).QueryRow(ctx, query, tenderId))
+}
+
+// saveTenderHistory сохраняет снимок текущей версии тендера вместе с его лотами и вложениями в tender_history.
+func (r *PostgresTenderRepository) saveTenderHistory(ctx context.Context, tender *models.Tender) error {
+	historyInsertQuery := `INSERT INTO tender_history (` + tenderColumns + `, lots, attachments)
+	                       VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, ` + tenderSnapshotColumns("$1") + `)`
+	_, err := r.conn(ctx).Exec(
+		ctx,
+		historyInsertQuery,
+		tender.ID,
+		tender.Name,
+		tender.Description,
+		tender.ServiceType,
+		tender.Status,
+		tender.OrganizationID,
+		tender.Version,
+		tender.CreatedAt,
+		tender.CreatorUsername,
+		tender.SubmissionDeadline,
+		tender.DecisionDeadline,
+		tender.Sealed,
+		tender.BidsOpeningAt,
+		tender.BidsOpenedAt)
+	return err
+}
+
+// Ключи сортировки списков тендеров.
+var (
+	tenderNameKey    = keysetKey[models.Tender]{column: "name", cast: "text", value: func(t *models.Tender) any { return t.Name }}
+	tenderIdKey      = keysetKey[models.Tender]{column: "id", cast: "uuid", value: func(t *models.Tender) any { return t.ID }}
+	tenderCreatedKey = keysetKey[models.Tender]{column: "created_at", cast: "timestamp", value: func(t *models.Tender) any { return keysetTime(t.CreatedAt) }}
+	tenderVersionKey = keysetKey[models.Tender]{column: "version", cast: "numeric", value: func(t *models.Tender) any { return t.Version }}
+	tenderStatusKey  = keysetKey[models.Tender]{column: "status", cast: "text", value: func(t *models.Tender) any { return string(t.Status) }}
+)
+
+// tenderListOrder - порядок списков тендеров по умолчанию: по названию, при равных названиях - по идентификатору.
+var tenderListOrder = keysetOrder[models.Tender]{tenderNameKey, tenderIdKey}
+
+// tenderSortOrders сопоставляет допустимые значения сортировки списков тендеров с порядком сортировки.
+var tenderSortOrders = map[string]keysetOrder[models.Tender]{
+	"":                       tenderListOrder,
+	models.SortName:          tenderListOrder,
+	models.SortCreatedAt:     {tenderCreatedKey, tenderIdKey},
+	models.SortCreatedAtDesc: {descending(tenderCreatedKey), descending(tenderIdKey)},
+	models.SortVersion:       {tenderVersionKey, tenderIdKey},
+	models.SortStatus:        {tenderStatusKey, tenderNameKey, tenderIdKey},
+}
+
+// tenderOrder возвращает порядок сортировки списка тендеров. Значение sort должно быть проверено заранее,
+// неизвестная сортировка заменяется сортировкой по названию.
+func tenderOrder(sort string) keysetOrder[models.Tender] {
+	if order, ok := tenderSortOrders[sort]; ok {
+		return order
+	}
+	return tenderListOrder
+}
+
+// GetTenders возвращает страницу списка тендеров, видимых пользователю viewerId, с учётом фильтров и сортировки.
+// Анонимному пользователю (пустой viewerId) доступны только опубликованные тендеры.
+func (r *PostgresTenderRepository) GetTenders(ctx context.Context, page models.Page, filter models.TenderFilter, viewerId string) (models.Paged[models.Tender], error) {
+	query, args, err := buildTendersQuery(page, filter, viewerId)
+	if err != nil {
+		return models.Paged[models.Tender]{}, err
+	}
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return models.Paged[models.Tender]{}, err
+	}
+	tenders, err := collectTenders(rows)
+	if err != nil {
+		return models.Paged[models.Tender]{}, err
+	}
+	return tenderOrder(filter.Sort).collect(tenders, page.Limit), nil
+}
+
+// buildTendersQuery формирует запрос страницы списка тендеров с учётом правил видимости:
+// опубликованные тендеры видны всем, тендеры в остальных статусах - только ответственным за организацию.
+func buildTendersQuery(page models.Page, filter models.TenderFilter, viewerId string) (string, []interface{}, error) {
+	var filters []string
+	var args []interface{}
+	argIndex := 1
+
+	if viewerId == "" {
+		filters = append(filters, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, models.PublishedTender)
+		argIndex++
+	} else {
+		filters = append(filters, fmt.Sprintf(
+			"(status = $%d OR organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = $%d))",
+			argIndex, argIndex+1))
+		args = append(args, models.PublishedTender, viewerId)
+		argIndex += 2
+	}
+
+	if len(filter.ServiceTypes) > 0 {
+		filters = append(filters, fmt.Sprintf("service_type = ANY($%d)", argIndex))
+		args = append(args, pq.Array(filter.ServiceTypes))
 	}
-	return tenders, nil
+	filters, args = appendListFilter(filters, args, filter.ListFilter, tenderOrganizationCondition)
+
+	tail, args, err := tenderOrder(filter.Sort).page(filters, args, page)
+	if err != nil {
+		return "", nil, err
+	}
+	return `SELECT ` + tenderColumns + ` FROM tender` + tail, args, nil
 }
 
 // CreateTender создает новый тендер.
@@ -94,10 +252,15 @@ func (r *PostgresTenderRepository) CreateTender(ctx context.Context, tenderReq m
 		Version:         1,
 		CreatedAt:       time.Now().UTC(),
 		CreatorUsername: tenderReq.CreatorUsername,
+
+		SubmissionDeadline: tenderReq.SubmissionDeadline,
+		DecisionDeadline:   tenderReq.DecisionDeadline,
+		Sealed:             tenderReq.Sealed,
+		BidsOpeningAt:      tenderReq.BidsOpeningAt,
 	}
-	_, err := r.DB.Exec(ctx, `
-       INSERT INTO tender (id, name, description, service_type, status, organization_id, version, created_at, creator_username)
-       VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	_, err := r.conn(ctx).Exec(ctx, `
+       INSERT INTO tender (`+tenderColumns+`)
+       VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
    `,
 		newTender.ID,
 		newTender.Name,
@@ -107,146 +270,111 @@ func (r *PostgresTenderRepository) CreateTender(ctx context.Context, tenderReq m
 		newTender.OrganizationID,
 		newTender.Version,
 		newTender.CreatedAt,
-		newTender.CreatorUsername)
+		newTender.CreatorUsername,
+		newTender.SubmissionDeadline,
+		newTender.DecisionDeadline,
+		newTender.Sealed,
+		newTender.BidsOpeningAt,
+		newTender.BidsOpenedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert tender: %w", err)
 	}
 	return &newTender, nil
 }
 
-// GetUserTender возвращает список тендеров для пользователя.
-func (r *PostgresTenderRepository) GetUserTender(ctx context.Context, limit, offset int, username string) ([]models.Tender, error) {
-	query := `SELECT id, name, description, service_type, status, organization_id, version, created_at, creator_username
-              FROM tender WHERE creator_username = $1 ORDER BY name LIMIT $2 OFFSET $3`
+// GetUserTender возвращает страницу списка тендеров, созданных пользователем, с учётом фильтров и сортировки.
+func (r *PostgresTenderRepository) GetUserTender(ctx context.Context, page models.Page, filter models.TenderFilter, username string) (models.Paged[models.Tender], error) {
+	filters, args := appendListFilter([]string{"creator_username = $1"}, []interface{}{username}, filter.ListFilter, tenderOrganizationCondition)
+	if len(filter.ServiceTypes) > 0 {
+		args = append(args, pq.Array(filter.ServiceTypes))
+		filters = append(filters, fmt.Sprintf("service_type = ANY($%d)", len(args)))
+	}
 
-	rows, err := r.DB.Query(ctx, query, username, limit, offset)
+	order := tenderOrder(filter.Sort)
+	tail, args, err := order.page(filters, args, page)
 	if err != nil {
-		return nil, err
+		return models.Paged[models.Tender]{}, err
 	}
-	defer rows.Close()
-
-	var tenders []models.Tender
-	for rows.Next() {
-		var t models.Tender
-		if err := rows.Scan(
-			&t.ID,
-			&t.Name,
-			&t.Description,
-			&t.ServiceType,
-			&t.Status,
-			&t.OrganizationID,
-			&t.Version,
-			&t.CreatedAt,
-			&t.CreatorUsername); err != nil {
-			return nil, err
-		}
-
-		isResponsible, err := utils.CheckUserResponsibleForOrganization(ctx, r.DB, username, t.OrganizationID)
-		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check responsibility")
-		}
-		if !isResponsible {
-			return nil, models.NewErrorResponse(http.StatusForbidden, "you do not have permission to view tenders for this organization")
-		}
-		tenders = append(tenders, t)
+	rows, err := r.conn(ctx).Query(ctx, `SELECT `+tenderColumns+` FROM tender`+tail, args...)
+	if err != nil {
+		return models.Paged[models.Tender]{}, err
+	}
+	tenders, err := collectTenders(rows)
+	if err != nil {
+		return models.Paged[models.Tender]{}, err
 	}
-	return tenders, nil
+	return order.collect(tenders, page.Limit), nil
 }
 
 // GetTenderStatus возвращает статус тендера.
-func (r *PostgresTenderRepository) GetTenderStatus(ctx context.Context, tenderId, username string) (models.TenderStatus, error) {
+func (r *PostgresTenderRepository) GetTenderStatus(ctx context.Context, tenderId string) (models.TenderStatus, error) {
 	var status models.TenderStatus
-	var query string
-	var args []interface{}
-
-	if username == "" {
-		query = `SELECT status FROM tender WHERE id = $1`
-		args = append(args, tenderId)
-	} else {
-		query = `SELECT status FROM tender WHERE id = $1 AND creator_username = $2`
-		args = append(args, tenderId, username)
-	}
-
-	err := r.DB.QueryRow(ctx, query, args...).Scan(&status)
+	query := `SELECT status FROM tender WHERE id = $1`
+	err := r.conn(ctx).QueryRow(ctx, query, tenderId).Scan(&status)
 	if err != nil {
 		return "", err
 	}
-
 	return status, nil
 }
 
-// UpdateTenderStatus меняет статус тендера.
-func (r *PostgresTenderRepository) UpdateTenderStatus(ctx context.Context, tenderId, status string) (*models.Tender, error) {
-	updateQuery := `UPDATE tender SET status = $1 WHERE id = $2`
-	_, err := r.DB.Exec(ctx, updateQuery, status, tenderId)
+// tenderStatusTransitions - допустимые переходы между статусами тендера.
+var tenderStatusTransitions = map[models.TenderStatus][]models.TenderStatus{
+	models.CreatedTender:   {models.PublishedTender, models.ClosedTender},
+	models.PublishedTender: {models.ClosedTender},
+	models.ClosedTender:    {},
+}
+
+// UpdateTenderStatus меняет статус тендера. Смена статуса создаёт новую версию тендера:
+// текущая версия блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+// Ожидаемая версия, если она передана, и допустимость перехода проверяются под блокировкой строки,
+// чтобы параллельные смены статуса не обошли друг друга.
+func (r *PostgresTenderRepository) UpdateTenderStatus(ctx context.Context, tenderId, status string, expectedVersion int) (*models.Tender, error) {
+	currentTender, err := r.lockTender(ctx, tenderId)
 	if err != nil {
 		return nil, err
 	}
-
-	var updatedTender models.Tender
-	selectQuery := `SELECT id, name, description, service_type, status, organization_id, version, created_at, creator_username
-	                FROM tender WHERE id = $1`
-	err = r.DB.QueryRow(ctx, selectQuery, tenderId).Scan(
-		&updatedTender.ID,
-		&updatedTender.Name,
-		&updatedTender.Description,
-		&updatedTender.ServiceType,
-		&updatedTender.Status,
-		&updatedTender.OrganizationID,
-		&updatedTender.Version,
-		&updatedTender.CreatedAt,
-		&updatedTender.CreatorUsername,
-	)
-	if err != nil {
+	if err = checkExpectedVersion(expectedVersion, int(currentTender.Version)); err != nil {
 		return nil, err
 	}
-	return &updatedTender, nil
+	if !slices.Contains(tenderStatusTransitions[currentTender.Status], models.TenderStatus(status)) {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid tender status")
+	}
+	return r.setTenderStatus(ctx, currentTender, models.TenderStatus(status))
 }
 
-// EditTender меняет описание тендера.
-func (r *PostgresTenderRepository) EditTender(ctx context.Context, tenderId string, updateFields map[string]interface{}) (*models.Tender, error) {
-	var currentTender models.Tender
-	selectQuery := `SELECT id, name, description, service_type, status, organization_id, version, created_at, creator_username
-	                FROM tender WHERE id = $1`
-	err := r.DB.QueryRow(ctx, selectQuery, tenderId).Scan(
-		&currentTender.ID,
-		&currentTender.Name,
-		&currentTender.Description,
-		&currentTender.ServiceType,
-		&currentTender.Status,
-		&currentTender.OrganizationID,
-		&currentTender.Version,
-		&currentTender.CreatedAt,
-		&currentTender.CreatorUsername,
-	)
+// CloseTender закрывает тендер, например после одобрения предложения по нему. Закрытие создаёт новую версию
+// тендера, поэтому метод следует вызывать внутри транзакции. Если тендер уже закрыт, возвращается nil.
+func (r *PostgresTenderRepository) CloseTender(ctx context.Context, tenderId string) (*models.Tender, error) {
+	currentTender, err := r.lockTender(ctx, tenderId)
 	if err != nil {
 		return nil, err
 	}
+	if currentTender.Status == models.ClosedTender {
+		return nil, nil
+	}
+	return r.setTenderStatus(ctx, currentTender, models.ClosedTender)
+}
 
-	var maxVersion int
-	versionQuery := `SELECT COALESCE(MAX(version), 0) FROM tender_history WHERE id = $1`
-	err = r.DB.QueryRow(ctx, versionQuery, currentTender.ID).Scan(&maxVersion)
-	if err != nil {
+// setTenderStatus сохраняет заблокированную версию тендера в истории и переводит тендер
+// в статус status с увеличением версии.
+func (r *PostgresTenderRepository) setTenderStatus(ctx context.Context, currentTender *models.Tender, status models.TenderStatus) (*models.Tender, error) {
+	if err := r.saveTenderHistory(ctx, currentTender); err != nil {
 		return nil, err
 	}
+	updateQuery := `UPDATE tender SET status = $1, version = version + 1 WHERE id = $2 RETURNING ` + tenderColumns
+	return scanTender(r.conn(ctx).QueryRow(ctx, updateQuery, status, currentTender.ID))
+}
 
-	historyInsertQuery := `INSERT INTO tender_history (id, name, description, service_type, status, organization_id, version, created_at, creator_username)
-                      VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	_, err = r.DB.Exec(
-		ctx,
-		historyInsertQuery,
-		currentTender.ID,
-		currentTender.Name,
-		currentTender.Description,
-		currentTender.ServiceType,
-		currentTender.Status,
-		currentTender.OrganizationID,
-		maxVersion+1,
-		currentTender.CreatedAt,
-		currentTender.CreatorUsername)
+// EditTender меняет описание тендера.
+// Текущая версия блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) EditTender(ctx context.Context, tenderId string, expectedVersion int, updateFields map[string]interface{}) (*models.Tender, error) {
+	currentTender, err := r.lockTender(ctx, tenderId)
 	if err != nil {
 		return nil, err
 	}
+	if err = checkExpectedVersion(expectedVersion, int(currentTender.Version)); err != nil {
+		return nil, err
+	}
 
 	updateQuery := `UPDATE tender SET `
 	var updates []string
@@ -279,89 +407,235 @@ func (r *PostgresTenderRepository) EditTender(ctx context.Context, tenderId stri
 		argIndex++
 	}
 
+	submissionDeadline, decisionDeadline := currentTender.SubmissionDeadline, currentTender.DecisionDeadline
+	if value, ok := updateFields["submissionDeadline"]; ok {
+		if submissionDeadline, err = parseDeadline("submissionDeadline", value); err != nil {
+			return nil, err
+		}
+		if submissionDeadline != nil && !submissionDeadline.After(time.Now()) {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "submissionDeadline must be in the future")
+		}
+		updates = append(updates, fmt.Sprintf("submission_deadline = $%d", argIndex))
+		args = append(args, submissionDeadline)
+		argIndex++
+	}
+
+	if value, ok := updateFields["decisionDeadline"]; ok {
+		if decisionDeadline, err = parseDeadline("decisionDeadline", value); err != nil {
+			return nil, err
+		}
+		updates = append(updates, fmt.Sprintf("decision_deadline = $%d", argIndex))
+		args = append(args, decisionDeadline)
+		argIndex++
+	}
+
+	if err = models.ValidateTenderDeadlines(submissionDeadline, decisionDeadline); err != nil {
+		return nil, err
+	}
+
+	_, sealedChanged := updateFields["sealed"]
+	_, openingChanged := updateFields["bidsOpeningAt"]
+	if (sealedChanged || openingChanged) && currentTender.Status != models.CreatedTender {
+		return nil, models.NewErrorResponse(http.StatusConflict, "sealed mode can only be changed before the tender is published")
+	}
+
+	if sealedChanged {
+		sealed, ok := updateFields["sealed"].(bool)
+		if !ok {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid sealed, must be a boolean")
+		}
+		updates = append(updates, fmt.Sprintf("sealed = $%d", argIndex))
+		args = append(args, sealed)
+		argIndex++
+	}
+
+	if openingChanged {
+		bidsOpeningAt, err := parseDeadline("bidsOpeningAt", updateFields["bidsOpeningAt"])
+		if err != nil {
+			return nil, err
+		}
+		if bidsOpeningAt != nil && !bidsOpeningAt.After(time.Now()) {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "bidsOpeningAt must be in the future")
+		}
+		updates = append(updates, fmt.Sprintf("bids_opening_at = $%d", argIndex))
+		args = append(args, bidsOpeningAt)
+		argIndex++
+	}
+
 	if len(updates) == 0 {
 		return nil, models.NewErrorResponse(http.StatusBadRequest, "No valid fields to update")
 	}
 
-	updates = append(updates, fmt.Sprintf("version = version + 1"))
+	if err = r.saveTenderHistory(ctx, currentTender); err != nil {
+		return nil, err
+	}
 
-	updateQuery += strings.Join(updates, ", ") + fmt.Sprintf(" WHERE id = $%d RETURNING id, name, description, service_type, status, organization_id, version, created_at, creator_username", argIndex)
+	updates = append(updates, "version = version + 1")
+
+	updateQuery += strings.Join(updates, ", ") + fmt.Sprintf(" WHERE id = $%d RETURNING %s", argIndex, tenderColumns)
 	args = append(args, tenderId)
 
-	var updatedTender models.Tender
-	err = r.DB.QueryRow(ctx, updateQuery, args...).Scan(
-		&updatedTender.ID,
-		&updatedTender.Name,
-		&updatedTender.Description,
-		&updatedTender.ServiceType,
-		&updatedTender.Status,
-		&updatedTender.OrganizationID,
-		&updatedTender.Version,
-		&updatedTender.CreatedAt,
-		&updatedTender.CreatorUsername,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return &updatedTender, nil
+	return scanTender(r.conn(ctx).QueryRow(ctx, updateQuery, args...))
 }
 
-// RollbackTender откатывает версию тендера
-func (r *PostgresTenderRepository) RollbackTender(ctx context.Context, tenderId string, version int) (*models.Tender, error) {
-	var organizationId string
-	query := `SELECT organization_id FROM tender WHERE id = $1`
-	err := r.DB.QueryRow(ctx, query, tenderId).Scan(&organizationId)
+// RollbackTender откатывает версию тендера вместе с наборами его лотов и вложений.
+// Текущая версия блокируется и сохраняется в истории, поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) RollbackTender(ctx context.Context, tenderId string, version, expectedVersion int) (*models.Tender, error) {
+	currentTender, err := r.lockTender(ctx, tenderId)
 	if err != nil {
 		return nil, err
 	}
+	if err = checkExpectedVersion(expectedVersion, int(currentTender.Version)); err != nil {
+		return nil, err
+	}
 
-	var rollbackVersion models.Tender
-	query = `SELECT id, name, description, service_type, status, organization_id, version, created_at, creator_username
+	query := `SELECT ` + tenderColumns + `, lots, attachments
 	         FROM tender_history WHERE id = $1 AND version = $2`
-	err = r.DB.QueryRow(ctx, query, tenderId, version).Scan(
-		&rollbackVersion.ID,
-		&rollbackVersion.Name,
-		&rollbackVersion.Description,
-		&rollbackVersion.ServiceType,
-		&rollbackVersion.Status,
-		&rollbackVersion.OrganizationID,
-		&rollbackVersion.Version,
-		&rollbackVersion.CreatedAt,
-		&rollbackVersion.CreatorUsername,
-	)
+	rollbackVersion, err := scanTenderVersion(r.conn(ctx).QueryRow(ctx, query, tenderId, version))
 	if err != nil {
 		return nil, err
 	}
 
-	updateQuery := `UPDATE tender SET name = $1, description = $2, service_type = $3, status = $4, version = version + 1 WHERE id = $5 RETURNING id, name, description, service_type, status, organization_id, version, created_at, creator_username`
-	var updatedTender models.Tender
-	err = r.DB.QueryRow(
+	if err = r.saveTenderHistory(ctx, currentTender); err != nil {
+		return nil, err
+	}
+	if err = r.replaceTenderLots(ctx, tenderId, rollbackVersion.Lots); err != nil {
+		return nil, err
+	}
+	if err = tenderAttachments.replace(ctx, r.conn(ctx), tenderId, rollbackVersion.Attachments); err != nil {
+		return nil, err
+	}
+
+	// Закрытый режим меняется только до публикации, поэтому откат опубликованного тендера его не затрагивает.
+	if currentTender.Status != models.CreatedTender {
+		rollbackVersion.Sealed, rollbackVersion.BidsOpeningAt = currentTender.Sealed, currentTender.BidsOpeningAt
+	}
+
+	updateQuery := `UPDATE tender SET name = $1, description = $2, service_type = $3, status = $4,
+	                submission_deadline = $5, decision_deadline = $6, sealed = $7, bids_opening_at = $8,
+	                version = version + 1
+	                WHERE id = $9 RETURNING ` + tenderColumns
+	return scanTender(r.conn(ctx).QueryRow(
 		ctx,
 		updateQuery,
 		rollbackVersion.Name,
 		rollbackVersion.Description,
 		rollbackVersion.ServiceType,
 		rollbackVersion.Status,
-		tenderId).Scan(
-		&updatedTender.ID,
-		&updatedTender.Name,
-		&updatedTender.Description,
-		&updatedTender.ServiceType,
-		&updatedTender.Status,
-		&updatedTender.OrganizationID,
-		&updatedTender.Version,
-		&updatedTender.CreatedAt,
-		&updatedTender.CreatorUsername,
-	)
+		rollbackVersion.SubmissionDeadline,
+		rollbackVersion.DecisionDeadline,
+		rollbackVersion.Sealed,
+		rollbackVersion.BidsOpeningAt,
+		tenderId))
+}
+
+// GetTenderVersions возвращает все версии тендера вместе с лотами и вложениями по возрастанию: снимки из истории и текущую версию.
+func (r *PostgresTenderRepository) GetTenderVersions(ctx context.Context, tenderId string) ([]models.Tender, error) {
+	query := `SELECT ` + tenderColumns + `, lots, attachments FROM tender_history WHERE id = $1
+	          UNION ALL
+	          SELECT ` + tenderColumns + `, ` + tenderSnapshotColumns("tender.id") + ` FROM tender WHERE id = $1
+	          ORDER BY version`
+	rows, err := r.conn(ctx).Query(ctx, query, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenders []models.Tender
+	for rows.Next() {
+		tender, err := scanTenderVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		tenders = append(tenders, *tender)
+	}
+	return tenders, rows.Err()
+}
+
+// GetTenderVersion возвращает снимок тендера указанной версии вместе с лотами и вложениями.
+func (r *PostgresTenderRepository) GetTenderVersion(ctx context.Context, tenderId string, version int) (*models.Tender, error) {
+	query := `SELECT ` + tenderColumns + `, lots, attachments FROM tender_history WHERE id = $1 AND version = $2
+	          UNION ALL
+	          SELECT ` + tenderColumns + `, ` + tenderSnapshotColumns("tender.id") + ` FROM tender WHERE id = $1 AND version = $2
+	          LIMIT 1`
+	return scanTenderVersion(r.conn(ctx).QueryRow(ctx, query, tenderId, version))
+}
+
+// CloseExpiredTenders закрывает не более limit опубликованных тендеров, срок подачи предложений по которым истёк к now.
+// Тендеры, заблокированные другими транзакциями, пропускаются. Закрываемые версии сохраняются в истории,
+// поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) CloseExpiredTenders(ctx context.Context, now time.Time, limit int) ([]models.Tender, error) {
+	query := `SELECT ` + tenderColumns + ` FROM tender
+	          WHERE status = $1 AND submission_deadline <= $2
+	          ORDER BY submission_deadline
+	          LIMIT $3
+	          FOR UPDATE SKIP LOCKED`
+	rows, err := r.conn(ctx).Query(ctx, query, models.PublishedTender, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	expiredTenders, err := collectTenders(rows)
 	if err != nil {
 		return nil, err
 	}
 
-	historyInsertQuery := `INSERT INTO tender_history (id, name, description, service_type, status, organization_id, version, created_at, creator_username)
-	                       VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	_, err = r.DB.Exec(ctx, historyInsertQuery, updatedTender.ID, updatedTender.Name, updatedTender.Description, updatedTender.ServiceType, updatedTender.Status, updatedTender.OrganizationID, updatedTender.Version, updatedTender.CreatedAt, updatedTender.CreatorUsername)
+	closedTenders := make([]models.Tender, 0, len(expiredTenders))
+	for i := range expiredTenders {
+		closedTender, err := r.setTenderStatus(ctx, &expiredTenders[i], models.ClosedTender)
+		if err != nil {
+			return nil, err
+		}
+		closedTenders = append(closedTenders, *closedTender)
+	}
+	return closedTenders, nil
+}
+
+// OpenSealedBids вскрывает предложения не более limit закрытых тендеров, которые закрыты
+// или назначенное время вскрытия которых наступило к now. Вскрытие создаёт новую версию тендера,
+// поэтому метод следует вызывать внутри транзакции.
+func (r *PostgresTenderRepository) OpenSealedBids(ctx context.Context, now time.Time, limit int) ([]models.Tender, error) {
+	query := `SELECT ` + tenderColumns + ` FROM tender
+	          WHERE sealed AND bids_opened_at IS NULL AND (status = $1 OR bids_opening_at <= $2)
+	          ORDER BY bids_opening_at NULLS FIRST
+	          LIMIT $3
+	          FOR UPDATE SKIP LOCKED`
+	rows, err := r.conn(ctx).Query(ctx, query, models.ClosedTender, now, limit)
 	if err != nil {
 		return nil, err
 	}
-	return &updatedTender, nil
+	sealedTenders, err := collectTenders(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	openedTenders := make([]models.Tender, 0, len(sealedTenders))
+	updateQuery := `UPDATE tender SET bids_opened_at = $1, version = version + 1 WHERE id = $2 RETURNING ` + tenderColumns
+	for i := range sealedTenders {
+		if err = r.saveTenderHistory(ctx, &sealedTenders[i]); err != nil {
+			return nil, err
+		}
+		openedTender, err := scanTender(r.conn(ctx).QueryRow(ctx, updateQuery, now, sealedTenders[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		openedTenders = append(openedTenders, *openedTender)
+	}
+	return openedTenders, nil
+}
+
+// parseDeadline разбирает срок из тела запроса на редактирование: строку в формате RFC 3339 или null.
+func parseDeadline(field string, value interface{}) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid %s, must be an RFC 3339 timestamp or null", field))
+	}
+	deadline, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid %s, must be an RFC 3339 timestamp or null", field))
+	}
+	deadline = deadline.UTC()
+	return &deadline, nil
 }
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// whereClause возвращает условие WHERE запроса без ORDER BY, LIMIT и OFFSET.
+func whereClause(t *testing.T, query string) string {
+	t.Helper()
+	start := strings.Index(query, " WHERE ")
+	end := strings.Index(query, " ORDER BY ")
+	if start < 0 || end < start {
+		t.Fatalf("query has no WHERE clause: %s", query)
+	}
+	return query[start+len(" WHERE ") : end]
+}
+
+func TestBuildTendersQuery(t *testing.T) {
+	const (
+		responsibleId = "0b6f7a3c-1d52-4f1e-9a57-3c1f2b8e4d10"
+		outsiderId    = "7c2e9d41-58a3-4b6f-8e0d-92f1a6c3b7e5"
+	)
+	viewerCondition := "(status = $1 OR organization_id IN " +
+		"(SELECT organization_id FROM organization_responsible WHERE user_id = $2))"
+	page := models.Page{Limit: 5, Offset: 10}
+
+	tests := []struct {
+		name      string
+		viewerId  string
+		filter    models.TenderFilter
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "anonymous sees only published tenders",
+			viewerId:  "",
+			wantWhere: "status = $1",
+			wantArgs:  []interface{}{models.PublishedTender, 6, 10},
+		},
+		{
+			// Тендеры своей организации в статусах Created и Closed отбирает подзапрос по organization_responsible.
+			name:      "responsible sees published and own organization tenders",
+			viewerId:  responsibleId,
+			wantWhere: viewerCondition,
+			wantArgs:  []interface{}{models.PublishedTender, responsibleId, 6, 10},
+		},
+		{
+			// Для пользователя без организаций подзапрос пуст, и остаются только опубликованные тендеры.
+			name:      "authenticated non-responsible sees published tenders",
+			viewerId:  outsiderId,
+			wantWhere: viewerCondition,
+			wantArgs:  []interface{}{models.PublishedTender, outsiderId, 6, 10},
+		},
+		{
+			name:     "anonymous with filters",
+			viewerId: "",
+			filter: models.TenderFilter{
+				ListFilter:   models.ListFilter{Statuses: []string{"Created"}},
+				ServiceTypes: []string{"Delivery"},
+			},
+			wantWhere: "status = $1 AND service_type = ANY($2) AND status = ANY($3)",
+			wantArgs: []interface{}{models.PublishedTender, pq.Array([]string{"Delivery"}),
+				pq.Array([]string{"Created"}), 6, 10},
+		},
+		{
+			name:     "responsible with filters",
+			viewerId: responsibleId,
+			filter: models.TenderFilter{
+				ListFilter:   models.ListFilter{Statuses: []string{"Closed"}},
+				ServiceTypes: []string{"Construction"},
+			},
+			wantWhere: viewerCondition + " AND service_type = ANY($3) AND status = ANY($4)",
+			wantArgs: []interface{}{models.PublishedTender, responsibleId, pq.Array([]string{"Construction"}),
+				pq.Array([]string{"Closed"}), 6, 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := buildTendersQuery(page, tt.filter, tt.viewerId)
+			if err != nil {
+				t.Fatalf("buildTendersQuery() error = %v", err)
+			}
+			if where := whereClause(t, query); where != tt.wantWhere {
+				t.Errorf("WHERE = %q, want %q", where, tt.wantWhere)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// Измерения фасетов поиска тендеров: фильтр по измерению не учитывается при подсчёте его фасета.
+const (
+	searchFacetNone        = ""
+	searchFacetServiceType = "service_type"
+	searchFacetStatus      = "status"
+)
+
+// tenderSearchQuery - полнотекстовый запрос по русской и английской конфигурациям.
+// Вхождение %d заменяется номером параметра с текстом запроса.
+const tenderSearchQuery = `(websearch_to_tsquery('russian', $%[1]d) || websearch_to_tsquery('english', $%[1]d))`
+
+// SearchTenders ищет тендеры, видимые пользователю viewerId, и возвращает страницу результатов, общее число
+// найденных тендеров и фасеты по типу услуги и статусу. При непустом запросе тендеры упорядочены по релевантности,
+// иначе - от новых к старым.
+func (r *PostgresTenderRepository) SearchTenders(ctx context.Context, search models.TenderSearch, viewerId string, limit, offset int) (*models.TenderSearchResult, error) {
+	result := &models.TenderSearchResult{
+		Tenders: []models.TenderSearchHit{},
+		Facets: models.TenderFacets{
+			ServiceType: map[string]int{},
+			Status:      map[string]int{},
+		},
+	}
+
+	where, args := buildTenderSearchFilter(search, viewerId, searchFacetNone)
+	rankExpr := "0::float8"
+	orderBy := "created_at DESC, id"
+	if search.Query != "" {
+		rankExpr = fmt.Sprintf("ts_rank_cd(search_vector, "+tenderSearchQuery+")::float8", len(args))
+		orderBy = "rank DESC, created_at DESC, id"
+	}
+	query := fmt.Sprintf(`SELECT `+tenderColumns+`, %s AS rank FROM tender WHERE %s ORDER BY %s LIMIT $%d OFFSET $%d`,
+		rankExpr, where, orderBy, len(args)+1, len(args)+2)
+	rows, err := r.conn(ctx).Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hit models.TenderSearchHit
+		if err = rows.Scan(append(tenderScanTargets(&hit.Tender), &hit.Rank)...); err != nil {
+			return nil, err
+		}
+		result.Tenders = append(result.Tenders, hit)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	countQuery := `SELECT COUNT(*) FROM tender WHERE ` + where
+	if err = r.conn(ctx).QueryRow(ctx, countQuery, args...).Scan(&result.Total); err != nil {
+		return nil, err
+	}
+
+	if err = r.countTenderFacet(ctx, search, viewerId, searchFacetServiceType, result.Facets.ServiceType); err != nil {
+		return nil, err
+	}
+	if err = r.countTenderFacet(ctx, search, viewerId, searchFacetStatus, result.Facets.Status); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// countTenderFacet подсчитывает найденные тендеры по значениям столбца facet без учёта фильтра по нему.
+func (r *PostgresTenderRepository) countTenderFacet(ctx context.Context, search models.TenderSearch, viewerId, facet string, counts map[string]int) error {
+	where, args := buildTenderSearchFilter(search, viewerId, facet)
+	query := fmt.Sprintf(`SELECT %[1]s, COUNT(*) FROM tender WHERE %[2]s GROUP BY %[1]s`, facet, where)
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var value string
+		var count int
+		if err = rows.Scan(&value, &count); err != nil {
+			return err
+		}
+		counts[value] = count
+	}
+	return rows.Err()
+}
+
+// buildTenderSearchFilter формирует условие поиска тендеров с учётом правил видимости, как в buildTendersQuery,
+// и фильтров поиска, кроме фильтра по измерению skipFacet. Параметр с текстом запроса, если он есть, - последний.
+func buildTenderSearchFilter(search models.TenderSearch, viewerId, skipFacet string) (string, []interface{}) {
+	var filters []string
+	var args []interface{}
+	argIndex := 1
+
+	if viewerId == "" {
+		filters = append(filters, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, models.PublishedTender)
+		argIndex++
+	} else {
+		filters = append(filters, fmt.Sprintf(
+			"(status = $%d OR organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = $%d))",
+			argIndex, argIndex+1))
+		args = append(args, models.PublishedTender, viewerId)
+		argIndex += 2
+	}
+
+	if len(search.Statuses) > 0 && skipFacet != searchFacetStatus {
+		filters = append(filters, fmt.Sprintf("status = ANY($%d)", argIndex))
+		args = append(args, pq.Array(search.Statuses))
+		argIndex++
+	}
+
+	if len(search.ServiceTypes) > 0 && skipFacet != searchFacetServiceType {
+		filters = append(filters, fmt.Sprintf("service_type = ANY($%d)", argIndex))
+		args = append(args, pq.Array(search.ServiceTypes))
+		argIndex++
+	}
+
+	if len(search.OrganizationIDs) > 0 {
+		filters = append(filters, fmt.Sprintf("organization_id::text = ANY($%d)", argIndex))
+		args = append(args, pq.Array(search.OrganizationIDs))
+		argIndex++
+	}
+
+	if search.CreatedFrom != nil {
+		filters = append(filters, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, *search.CreatedFrom)
+		argIndex++
+	}
+
+	if search.CreatedTo != nil {
+		filters = append(filters, fmt.Sprintf("created_at <= $%d", argIndex))
+		args = append(args, *search.CreatedTo)
+		argIndex++
+	}
+
+	if search.BudgetFrom != nil || search.BudgetTo != nil {
+		budget := fmt.Sprintf("(SELECT SUM(l.max_budget) FROM tender_lot l WHERE l.tender_id = tender.id AND l.currency = $%d)", argIndex)
+		args = append(args, search.Currency)
+		argIndex++
+		if search.BudgetFrom != nil {
+			filters = append(filters, fmt.Sprintf("%s >= $%d", budget, argIndex))
+			args = append(args, *search.BudgetFrom)
+			argIndex++
+		}
+		if search.BudgetTo != nil {
+			filters = append(filters, fmt.Sprintf("%s <= $%d", budget, argIndex))
+			args = append(args, *search.BudgetTo)
+			argIndex++
+		}
+	}
+
+	if search.Query != "" {
+		filters = append(filters, fmt.Sprintf("search_vector @@ "+tenderSearchQuery, argIndex))
+		args = append(args, search.Query)
+	}
+
+	return strings.Join(filters, " AND "), args
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/senyabanana/tender-service/internal/models"
+
+// checkExpectedVersion сравнивает ожидаемую клиентом версию объекта с текущей.
+// Нулевая ожидаемая версия означает, что клиент не передавал предусловие.
+func checkExpectedVersion(expectedVersion, currentVersion int) error {
+	if expectedVersion != 0 && expectedVersion != currentVersion {
+		return models.NewVersionConflictError(currentVersion)
+	}
+	return nil
+}
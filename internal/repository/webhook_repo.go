@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/events"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// webhookColumns - список столбцов таблицы webhook в порядке, ожидаемом scanWebhook. Секрет не выбирается.
+const webhookColumns = `id, organization_id, url, event_types, created_at`
+
+// WebhookRepository - интерфейс для работы с вебхуками организаций и очередью их доставки.
+type WebhookRepository interface {
+	GetWebhooks(ctx context.Context, organizationId string) ([]models.Webhook, error)
+	CreateWebhook(ctx context.Context, organizationId string, webhookReq models.WebhookRequest) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, organizationId, webhookId string) error
+	GetDeadLetters(ctx context.Context, organizationId, webhookId string, limit, offset int) ([]models.WebhookDeadLetter, error)
+	EnqueueDeliveries(ctx context.Context, event events.Event) error
+	ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error)
+	CompleteDelivery(ctx context.Context, deliveryId string) error
+	RetryDelivery(ctx context.Context, deliveryId string, nextAttemptAt time.Time, lastError string) error
+	DeadLetterDelivery(ctx context.Context, delivery models.WebhookDelivery, lastError string) error
+}
+
+// PostgresWebhookRepository - реализация WebhookRepository для базы данных.
+type PostgresWebhookRepository struct {
+	DB *pgxpool.Pool
+}
+
+// NewPostgresWebhookRepository создаёт новый экземпляр PostgresWebhookRepository.
+func NewPostgresWebhookRepository(db *pgxpool.Pool) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{DB: db}
+}
+
+// conn возвращает текущую транзакцию из контекста или пул соединений.
+func (r *PostgresWebhookRepository) conn(ctx context.Context) db.DBTX {
+	return db.Conn(ctx, r.DB)
+}
+
+// scanWebhook считывает вебхук из строки результата, выбранной по webhookColumns.
+func scanWebhook(row pgx.Row) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := row.Scan(&webhook.ID, &webhook.OrganizationID, &webhook.URL, &webhook.EventTypes, &webhook.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetWebhooks возвращает вебхуки организации в порядке регистрации.
+func (r *PostgresWebhookRepository) GetWebhooks(ctx context.Context, organizationId string) ([]models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook WHERE organization_id = $1 ORDER BY created_at, id`
+	rows, err := r.conn(ctx).Query(ctx, query, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// CreateWebhook регистрирует вебхук организации. Возвращаемый вебхук содержит секрет.
+func (r *PostgresWebhookRepository) CreateWebhook(ctx context.Context, organizationId string, webhookReq models.WebhookRequest) (*models.Webhook, error) {
+	eventTypes := webhookReq.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	query := `
+		INSERT INTO webhook (id, organization_id, url, event_types, secret, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + webhookColumns
+	webhook, err := scanWebhook(r.conn(ctx).QueryRow(ctx, query,
+		uuid.New().String(), organizationId, webhookReq.URL, eventTypes, webhookReq.Secret, time.Now().UTC()))
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = webhookReq.Secret
+	return webhook, nil
+}
+
+// DeleteWebhook удаляет вебхук организации вместе с очередью доставки и недоставленными событиями.
+// Если вебхук не найден, возвращается pgx.ErrNoRows.
+func (r *PostgresWebhookRepository) DeleteWebhook(ctx context.Context, organizationId, webhookId string) error {
+	tag, err := r.conn(ctx).Exec(ctx, `DELETE FROM webhook WHERE id = $1 AND organization_id = $2`, webhookId, organizationId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetDeadLetters возвращает недоставленные события вебхука организации, последние - первыми.
+func (r *PostgresWebhookRepository) GetDeadLetters(ctx context.Context, organizationId, webhookId string, limit, offset int) ([]models.WebhookDeadLetter, error) {
+	query := `
+		SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, d.last_error, d.created_at, d.failed_at
+		FROM webhook_dead_letter d
+		JOIN webhook w ON w.id = d.webhook_id
+		WHERE d.webhook_id = $1 AND w.organization_id = $2
+		ORDER BY d.failed_at DESC, d.id
+		LIMIT $3 OFFSET $4`
+	rows, err := r.conn(ctx).Query(ctx, query, webhookId, organizationId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deadLetters := []models.WebhookDeadLetter{}
+	for rows.Next() {
+		var deadLetter models.WebhookDeadLetter
+		err = rows.Scan(&deadLetter.ID, &deadLetter.WebhookID, &deadLetter.EventID, &deadLetter.EventType, &deadLetter.Payload,
+			&deadLetter.Attempts, &deadLetter.LastError, &deadLetter.CreatedAt, &deadLetter.FailedAt)
+		if err != nil {
+			return nil, err
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+	return deadLetters, rows.Err()
+}
+
+// EnqueueDeliveries ставит событие в очередь доставки всем вебхукам, подписанным на его тип.
+// События тендера получает организация тендера. События предложения получает организация, от имени которой
+// подано предложение, а организация тендера - только после публикации предложения, как и при просмотре через API.
+// Метод следует вызывать в той же транзакции, что и само изменение.
+func (r *PostgresWebhookRepository) EnqueueDeliveries(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	tenderOrganizationReceives := !event.IsBidEvent() || event.Status != string(models.CreatedBid)
+	query := `
+		INSERT INTO webhook_delivery (webhook_id, event_id, event_type, payload, next_attempt_at, created_at)
+		SELECT w.id, $1, $2, $3, $4, $4
+		FROM webhook w
+		WHERE (cardinality(w.event_types) = 0 OR $2 = ANY(w.event_types))
+		  AND w.organization_id IN (
+		      SELECT t.organization_id FROM tender t WHERE t.id::text = $5 AND $6
+		      UNION
+		      SELECT o.organization_id FROM bid b
+		      JOIN organization_responsible o ON o.user_id = b.author_id
+		      WHERE b.id::text = $7 AND b.author_type = 'Organization'
+		  )`
+	_, err = r.conn(ctx).Exec(ctx, query, event.ID, string(event.Type), payload, event.OccurredAt,
+		event.TenderID, tenderOrganizationReceives, event.BidID)
+	return err
+}
+
+// ClaimDeliveries выбирает не более limit доставок, время попытки которых наступило к моменту now,
+// увеличивает их счётчик попыток и откладывает следующую попытку до leaseUntil, чтобы другие
+// обработчики не взяли их, пока идёт отправка.
+func (r *PostgresWebhookRepository) ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_delivery d SET attempts = d.attempts + 1, next_attempt_at = $2
+		FROM webhook w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id FROM webhook_delivery
+			WHERE next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, d.created_at, w.url, w.secret`
+	rows, err := r.conn(ctx).Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		err = rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &delivery.Payload,
+			&delivery.Attempts, &delivery.CreatedAt, &delivery.URL, &delivery.Secret)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// CompleteDelivery удаляет успешно выполненную доставку из очереди.
+func (r *PostgresWebhookRepository) CompleteDelivery(ctx context.Context, deliveryId string) error {
+	_, err := r.conn(ctx).Exec(ctx, `DELETE FROM webhook_delivery WHERE id = $1`, deliveryId)
+	return err
+}
+
+// RetryDelivery назначает следующую попытку доставки на nextAttemptAt и сохраняет ошибку последней попытки.
+func (r *PostgresWebhookRepository) RetryDelivery(ctx context.Context, deliveryId string, nextAttemptAt time.Time, lastError string) error {
+	query := `UPDATE webhook_delivery SET next_attempt_at = $2, last_error = $3 WHERE id = $1`
+	_, err := r.conn(ctx).Exec(ctx, query, deliveryId, nextAttemptAt, lastError)
+	return err
+}
+
+// DeadLetterDelivery переносит доставку из очереди в таблицу недоставленных событий.
+// Метод следует вызывать внутри транзакции.
+func (r *PostgresWebhookRepository) DeadLetterDelivery(ctx context.Context, delivery models.WebhookDelivery, lastError string) error {
+	insertQuery := `
+		INSERT INTO webhook_dead_letter (id, webhook_id, event_id, event_type, payload, attempts, last_error, created_at, failed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.conn(ctx).Exec(ctx, insertQuery, delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType,
+		delivery.Payload, delivery.Attempts, lastError, delivery.CreatedAt, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	return r.CompleteDelivery(ctx, delivery.ID)
+}
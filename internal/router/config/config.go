@@ -1,6 +1,11 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 // Config - структура для хранения конфигураций приложения
 type Config struct {
@@ -13,13 +18,37 @@ type Config struct {
 	PostgresPort  string `mapstructure:"POSTGRES_PORT"`
 	PostgresDB    string `mapstructure:"POSTGRES_DATABASE"`
 	MigrationURL  string `mapstructure:"MIGRATION_URL"`
+
+	AuthSecret          string        `mapstructure:"AUTH_SECRET"`
+	AuthTokenTTL        time.Duration `mapstructure:"AUTH_TOKEN_TTL"`
+	CursorSecret        string        `mapstructure:"CURSOR_SECRET"`
+	SchedulerInterval   time.Duration `mapstructure:"SCHEDULER_INTERVAL"`
+	EventsBackend       string        `mapstructure:"EVENTS_BACKEND"`
+	WebhookInterval     time.Duration `mapstructure:"WEBHOOK_INTERVAL"`
+	OutboxEnabled       bool          `mapstructure:"OUTBOX_ENABLED"`
+	OutboxRelayInterval time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL"`
+	OutboxSinkFile      string        `mapstructure:"OUTBOX_SINK_FILE"`
+	AttachmentsDir      string        `mapstructure:"ATTACHMENTS_DIR"`
+	AttachmentMaxSize   int64         `mapstructure:"ATTACHMENT_MAX_SIZE"`
+	AttachmentTypes     []string      `mapstructure:"ATTACHMENT_CONTENT_TYPES"`
+	AuthLegacyUsername  bool          `mapstructure:"AUTH_LEGACY_USERNAME"`
 }
 
-// LoadConfig загружает конфигурацию из файла
+const (
+	// minSecretLength - минимальная длина секрета подписи в байтах.
+	minSecretLength = 32
+
+	// placeholderSecret - значение-заглушка, которое раньше стояло в app.env и не может служить секретом.
+	placeholderSecret = "change-me-in-production"
+)
+
+// LoadConfig загружает конфигурацию из файла. Переменные окружения с теми же именами
+// переопределяют значения из файла.
 func LoadConfig(path string) (cfg Config, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("app")
 	viper.SetConfigType("env")
+	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
 	if err != nil {
@@ -28,3 +57,17 @@ func LoadConfig(path string) (cfg Config, err error) {
 	err = viper.Unmarshal(&cfg)
 	return
 }
+
+// ValidateSecret проверяет секрет подписи из параметра name: он должен быть задан, не совпадать
+// с заглушкой и быть не короче minSecretLength байт.
+func ValidateSecret(name, secret string) error {
+	switch {
+	case secret == "":
+		return fmt.Errorf("%s must be set", name)
+	case secret == placeholderSecret:
+		return fmt.Errorf("%s must not be the placeholder value", name)
+	case len(secret) < minSecretLength:
+		return fmt.Errorf("%s must be at least %d bytes long", name, minSecretLength)
+	}
+	return nil
+}
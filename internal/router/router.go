@@ -6,17 +6,42 @@ import (
 	"github.com/senyabanana/tender-service/internal/handlers"
 )
 
-func InitRoutes(tenderHandler *handlers.TenderHandler, bidHandler *handlers.BidHandler) http.Handler {
+func InitRoutes(tenderHandler *handlers.TenderHandler, bidHandler *handlers.BidHandler, authHandler *handlers.AuthHandler, organizationHandler *handlers.OrganizationHandler, employeeHandler *handlers.EmployeeHandler, auditHandler *handlers.AuditHandler, evaluationHandler *handlers.EvaluationHandler, auctionHandler *handlers.AuctionHandler, eventHandler *handlers.EventHandler, webhookHandler *handlers.WebhookHandler, questionHandler *handlers.TenderQuestionHandler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/api/ping", handlers.PingHandler)
+	mux.HandleFunc("/api/auth/token", authHandler.IssueToken)
+
 	mux.HandleFunc("/api/tenders", tenderHandler.GetTenders)
 	mux.HandleFunc("/api/tenders/new", tenderHandler.CreateTender)
 	mux.HandleFunc("/api/tenders/my", tenderHandler.GetUserTender)
+	mux.HandleFunc("GET /api/tenders/search", tenderHandler.SearchTenders)
 	mux.HandleFunc("GET /api/tenders/{tenderId}/status", tenderHandler.GetTenderStatus)
 	mux.HandleFunc("PUT /api/tenders/{tenderId}/status", tenderHandler.UpdateTenderStatus)
 	mux.HandleFunc("/api/tenders/{tenderId}/edit", tenderHandler.EditTender)
 	mux.HandleFunc("/api/tenders/{tenderId}/rollback/{version}", tenderHandler.RollbackTender)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/versions", tenderHandler.GetTenderVersions)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/versions/{version}", tenderHandler.GetTenderVersion)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/diff", tenderHandler.DiffTenderVersions)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/lots", tenderHandler.GetTenderLots)
+	mux.HandleFunc("POST /api/tenders/{tenderId}/lots", tenderHandler.CreateTenderLot)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/lots/{lotId}", tenderHandler.GetTenderLot)
+	mux.HandleFunc("PATCH /api/tenders/{tenderId}/lots/{lotId}", tenderHandler.EditTenderLot)
+	mux.HandleFunc("DELETE /api/tenders/{tenderId}/lots/{lotId}", tenderHandler.DeleteTenderLot)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/attachments", tenderHandler.GetTenderAttachments)
+	mux.HandleFunc("POST /api/tenders/{tenderId}/attachments", tenderHandler.CreateTenderAttachment)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/attachments/{attachmentId}", tenderHandler.GetTenderAttachment)
+	mux.HandleFunc("DELETE /api/tenders/{tenderId}/attachments/{attachmentId}", tenderHandler.DeleteTenderAttachment)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/questions", questionHandler.GetQuestions)
+	mux.HandleFunc("POST /api/tenders/{tenderId}/questions", questionHandler.AskQuestion)
+	mux.HandleFunc("PUT /api/tenders/{tenderId}/questions/{questionId}/answer", questionHandler.AnswerQuestion)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/criteria", evaluationHandler.GetCriteria)
+	mux.HandleFunc("POST /api/tenders/{tenderId}/criteria", evaluationHandler.CreateCriterion)
+	mux.HandleFunc("DELETE /api/tenders/{tenderId}/criteria/{criterionId}", evaluationHandler.DeleteCriterion)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/evaluation", evaluationHandler.GetEvaluation)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/auction", auctionHandler.GetAuction)
+	mux.HandleFunc("PUT /api/tenders/{tenderId}/auction", auctionHandler.SaveAuction)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/auction/leaderboard", auctionHandler.GetLeaderboard)
 
 	mux.HandleFunc("/api/bids/new", bidHandler.CreateBid)
 	mux.HandleFunc("/api/bids/my", bidHandler.GetUserBid)
@@ -27,7 +52,41 @@ func InitRoutes(tenderHandler *handlers.TenderHandler, bidHandler *handlers.BidH
 	mux.HandleFunc("/api/bids/{bidId}/submit_decision", bidHandler.SubmitBidDecision)
 	mux.HandleFunc("/api/bids/{bidId}/feedback", bidHandler.SubmitBidFeedback)
 	mux.HandleFunc("/api/bids/{bidId}/rollback/{version}", bidHandler.RollbackBid)
+	mux.HandleFunc("GET /api/bids/{bidId}/versions", bidHandler.GetBidVersions)
+	mux.HandleFunc("GET /api/bids/{bidId}/versions/{version}", bidHandler.GetBidVersion)
+	mux.HandleFunc("GET /api/bids/{bidId}/diff", bidHandler.DiffBidVersions)
+	mux.HandleFunc("GET /api/bids/{bidId}/attachments", bidHandler.GetBidAttachments)
+	mux.HandleFunc("POST /api/bids/{bidId}/attachments", bidHandler.CreateBidAttachment)
+	mux.HandleFunc("GET /api/bids/{bidId}/attachments/{attachmentId}", bidHandler.GetBidAttachment)
+	mux.HandleFunc("DELETE /api/bids/{bidId}/attachments/{attachmentId}", bidHandler.DeleteBidAttachment)
+	mux.HandleFunc("GET /api/bids/messages/unread", bidHandler.GetUnreadBidMessages)
+	mux.HandleFunc("GET /api/bids/{bidId}/messages", bidHandler.GetBidMessages)
+	mux.HandleFunc("POST /api/bids/{bidId}/messages", bidHandler.SendBidMessage)
+	mux.HandleFunc("PUT /api/bids/{bidId}/scores", evaluationHandler.ScoreBid)
+	mux.HandleFunc("POST /api/bids/{bidId}/rebid", auctionHandler.Rebid)
 	mux.HandleFunc("/api/bids/{tenderId}/reviews", bidHandler.GetBidReviews)
 
-	return mux
+	mux.HandleFunc("POST /api/organizations", organizationHandler.CreateOrganization)
+	mux.HandleFunc("GET /api/organizations", organizationHandler.GetOrganizations)
+	mux.HandleFunc("GET /api/organizations/{organizationId}", organizationHandler.GetOrganization)
+	mux.HandleFunc("PATCH /api/organizations/{organizationId}", organizationHandler.UpdateOrganization)
+	mux.HandleFunc("DELETE /api/organizations/{organizationId}", organizationHandler.DeleteOrganization)
+	mux.HandleFunc("GET /api/organizations/{organizationId}/responsibles", organizationHandler.GetResponsibles)
+	mux.HandleFunc("PUT /api/organizations/{organizationId}/responsibles/{userId}", organizationHandler.AddResponsible)
+	mux.HandleFunc("DELETE /api/organizations/{organizationId}/responsibles/{userId}", organizationHandler.RemoveResponsible)
+	mux.HandleFunc("GET /api/organizations/{organizationId}/webhooks", webhookHandler.GetWebhooks)
+	mux.HandleFunc("POST /api/organizations/{organizationId}/webhooks", webhookHandler.CreateWebhook)
+	mux.HandleFunc("DELETE /api/organizations/{organizationId}/webhooks/{webhookId}", webhookHandler.DeleteWebhook)
+	mux.HandleFunc("GET /api/organizations/{organizationId}/webhooks/{webhookId}/dead-letters", webhookHandler.GetDeadLetters)
+
+	mux.HandleFunc("POST /api/employees", employeeHandler.CreateEmployee)
+	mux.HandleFunc("GET /api/employees", employeeHandler.GetEmployees)
+	mux.HandleFunc("GET /api/employees/{employeeId}", employeeHandler.GetEmployee)
+	mux.HandleFunc("PATCH /api/employees/{employeeId}", employeeHandler.UpdateEmployee)
+	mux.HandleFunc("DELETE /api/employees/{employeeId}", employeeHandler.DeleteEmployee)
+
+	mux.HandleFunc("GET /api/audit", auditHandler.GetEvents)
+	mux.HandleFunc("GET /api/events", eventHandler.StreamEvents)
+
+	return authMiddleware(mux)
 }
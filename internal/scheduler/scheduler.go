@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/services"
+)
+
+const (
+	// closeTendersLockKey - ключ advisory-блокировки, под которой закрываются тендеры.
+	// Пока одна реплика держит блокировку, планировщики остальных реплик пропускают запуск.
+	closeTendersLockKey int64 = 7_011_001
+
+	// closeTendersBatchSize - максимальное число тендеров, закрываемых за один запуск.
+	closeTendersBatchSize = 100
+)
+
+// Scheduler периодически закрывает опубликованные тендеры с истёкшим сроком подачи предложений,
+// вскрывает предложения закрытых тендеров и закрывает окончившиеся раунды аукционов.
+type Scheduler struct {
+	Tenders  *services.TenderService
+	Auctions *services.AuctionService
+	Interval time.Duration
+	Logger   *log.Logger
+	uow      *db.UnitOfWork
+}
+
+// NewScheduler создаёт новый экземпляр Scheduler.
+func NewScheduler(tenders *services.TenderService, auctions *services.AuctionService, uow *db.UnitOfWork, interval time.Duration, logger *log.Logger) *Scheduler {
+	return &Scheduler{
+		Tenders:  tenders,
+		Auctions: auctions,
+		Interval: interval,
+		Logger:   logger,
+		uow:      uow,
+	}
+}
+
+// Run запускает планировщик и блокируется до отмены контекста.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.closeExpiredTenders(ctx)
+		}
+	}
+}
+
+// closeExpiredTenders закрывает окончившиеся раунды аукционов и тендеры с истёкшим сроком,
+// вскрывает предложения закрытых тендеров, если блокировку не держит другая реплика.
+func (s *Scheduler) closeExpiredTenders(ctx context.Context) {
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		acquired, err := s.uow.TryAdvisoryLock(ctx, closeTendersLockKey)
+		if err != nil || !acquired {
+			return err
+		}
+
+		auctions, err := s.Auctions.CloseEndedRounds(ctx, closeTendersBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(auctions) > 0 {
+			s.Logger.Printf("scheduler: closed auction rounds of %d tenders", len(auctions))
+		}
+
+		closedTenders, err := s.Tenders.CloseExpiredTenders(ctx, closeTendersBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(closedTenders) > 0 {
+			s.Logger.Printf("scheduler: closed %d expired tenders", len(closedTenders))
+		}
+
+		openedTenders, err := s.Tenders.OpenSealedBids(ctx, closeTendersBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(openedTenders) > 0 {
+			s.Logger.Printf("scheduler: opened bids of %d sealed tenders", len(openedTenders))
+		}
+		return nil
+	})
+	if err != nil {
+		s.Logger.Printf("scheduler: failed to close expired tenders: %v", err)
+	}
+}
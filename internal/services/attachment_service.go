@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/events"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxAttachmentFileNameLength - максимальная длина имени файла вложения в символах.
+const maxAttachmentFileNameLength = 255
+
+// errAttachmentTooLarge возвращается при чтении вложения, размер которого превышает допустимый.
+var errAttachmentTooLarge = errors.New("attachment is too large")
+
+// AttachmentStorage сохраняет содержимое вложений в хранилище файлов и проверяет ограничения на загружаемые файлы:
+// максимальный размер в байтах и допустимые типы содержимого.
+type AttachmentStorage struct {
+	Store        storage.BlobStore
+	MaxSize      int64
+	ContentTypes []string
+}
+
+// NewAttachmentStorage создаёт новый экземпляр AttachmentStorage.
+func NewAttachmentStorage(store storage.BlobStore, maxSize int64, contentTypes []string) *AttachmentStorage {
+	return &AttachmentStorage{Store: store, MaxSize: maxSize, ContentTypes: contentTypes}
+}
+
+// tenderAttachmentKey возвращает ключ содержимого вложения тендера в хранилище файлов.
+func tenderAttachmentKey(tenderId, attachmentId string) string {
+	return "tenders/" + tenderId + "/" + attachmentId
+}
+
+// bidAttachmentKey возвращает ключ содержимого вложения предложения в хранилище файлов.
+func bidAttachmentKey(bidId, attachmentId string) string {
+	return "bids/" + bidId + "/" + attachmentId
+}
+
+// save проверяет имя и тип загружаемого файла, сохраняет его содержимое под ключом, полученным из идентификатора
+// нового вложения, и возвращает вложение с размером и контрольной суммой SHA-256 содержимого.
+func (a *AttachmentStorage) save(ctx context.Context, key func(attachmentId string) string, upload models.AttachmentUpload, uploadedBy string) (*models.Attachment, error) {
+	fileName, err := attachmentFileName(upload.FileName)
+	if err != nil {
+		return nil, err
+	}
+	contentType, err := a.contentType(fileName, upload.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &models.Attachment{
+		ID:          uuid.New().String(),
+		FileName:    fileName,
+		ContentType: contentType,
+		UploadedBy:  uploadedBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+	content := &attachmentReader{r: upload.Content, remaining: a.MaxSize, hash: sha256.New()}
+	err = a.Store.Put(ctx, key(attachment.ID), content)
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, errAttachmentTooLarge) || errors.As(err, &maxBytesErr):
+		return nil, models.NewErrorResponse(http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("attachment is too large, maximum size is %d bytes", a.MaxSize))
+	case err != nil:
+		return nil, err
+	}
+	if content.size == 0 {
+		_ = a.Store.Delete(ctx, key(attachment.ID))
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "attachment is empty")
+	}
+
+	attachment.Size = content.size
+	attachment.Checksum = hex.EncodeToString(content.hash.Sum(nil))
+	return attachment, nil
+}
+
+// contentType определяет тип содержимого файла по указанному клиентом типу, а если он не указан -
+// по расширению имени файла, и проверяет, что тип допустим.
+func (a *AttachmentStorage) contentType(fileName, declared string) (string, error) {
+	contentType := ""
+	if declared != "" {
+		mediaType, _, err := mime.ParseMediaType(declared)
+		if err != nil {
+			return "", models.NewErrorResponse(http.StatusBadRequest, "invalid attachment content type")
+		}
+		contentType = mediaType
+	}
+	if contentType == "" || contentType == "application/octet-stream" {
+		if byExtension, _, err := mime.ParseMediaType(mime.TypeByExtension(filepath.Ext(fileName))); err == nil {
+			contentType = byExtension
+		}
+	}
+	if !slices.Contains(a.ContentTypes, contentType) {
+		return "", models.NewErrorResponse(http.StatusUnsupportedMediaType,
+			fmt.Sprintf("unsupported attachment content type, must be one of: %s", strings.Join(a.ContentTypes, ", ")))
+	}
+	return contentType, nil
+}
+
+// attachmentFileName отбрасывает из имени файла путь и проверяет, что имя задано и не слишком длинное.
+func attachmentFileName(name string) (string, error) {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "" || name == "." || name == "/" {
+		return "", models.NewErrorResponse(http.StatusBadRequest, "missing attachment file name")
+	}
+	if !utf8.ValidString(name) || utf8.RuneCountInString(name) > maxAttachmentFileNameLength {
+		return "", models.NewErrorResponse(http.StatusBadRequest,
+			fmt.Sprintf("invalid attachment file name, must be a valid UTF-8 string of at most %d characters", maxAttachmentFileNameLength))
+	}
+	return name, nil
+}
+
+// attachmentReader считает размер и контрольную сумму читаемого содержимого и возвращает errAttachmentTooLarge,
+// как только прочитано больше remaining байт.
+type attachmentReader struct {
+	r         io.Reader
+	remaining int64
+	size      int64
+	hash      hash.Hash
+}
+
+// Read читает очередную часть содержимого.
+func (r *attachmentReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.size += int64(n)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return 0, errAttachmentTooLarge
+	}
+	r.hash.Write(p[:n])
+	return n, err
+}
+
+// open открывает содержимое вложения, сохранённое под ключом key.
+func (a *AttachmentStorage) open(ctx context.Context, key string) (io.ReadCloser, error) {
+	content, err := a.Store.Open(ctx, key)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, models.NewErrorResponse(http.StatusNotFound, "attachment content not found")
+	}
+	return content, err
+}
+
+// discard удаляет содержимое вложения, запись о котором не удалось сохранить.
+// Ошибка удаления не возвращается: оставшийся файл ни на что не ссылается.
+func (a *AttachmentStorage) discard(ctx context.Context, key string) {
+	_ = a.Store.Delete(context.WithoutCancel(ctx), key)
+}
+
+// attachmentNotFound заменяет отсутствие строки ошибкой 404 "attachment not found".
+func attachmentNotFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.NewErrorResponse(http.StatusNotFound, "attachment not found")
+	}
+	return err
+}
+
+// GetTenderAttachments получает список вложений тендера.
+func (s *TenderService) GetTenderAttachments(ctx context.Context, tenderId string) ([]models.Attachment, error) {
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetTenderAttachments(ctx, tenderId)
+}
+
+// GetTenderAttachmentContent получает вложение тендера и открывает его содержимое. Содержимое закрывает вызывающий.
+func (s *TenderService) GetTenderAttachmentContent(ctx context.Context, tenderId, attachmentId string) (*models.Attachment, io.ReadCloser, error) {
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return nil, nil, err
+	}
+	attachment, err := s.Repo.GetTenderAttachment(ctx, tenderId, attachmentId)
+	if err != nil {
+		return nil, nil, attachmentNotFound(err)
+	}
+	content, err := s.Attachments.open(ctx, tenderAttachmentKey(tenderId, attachment.ID))
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// CreateTenderAttachment загружает вложение тендера. Изменение вложений создаёт новую версию тендера,
+// поэтому ненулевая expectedVersion должна совпадать с текущей версией тендера.
+func (s *TenderService) CreateTenderAttachment(ctx context.Context, tenderId string, expectedVersion int, upload models.AttachmentUpload) (*models.Attachment, *models.Tender, error) {
+	if err := s.checkTenderEdit(ctx, tenderId); err != nil {
+		return nil, nil, err
+	}
+
+	key := func(attachmentId string) string { return tenderAttachmentKey(tenderId, attachmentId) }
+	attachment, err := s.Attachments.save(ctx, key, upload, auth.Username(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updatedTender *models.Tender
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTender, err = s.Repo.CreateTenderAttachment(ctx, tenderId, expectedVersion, *attachment)
+		if err != nil {
+			return err
+		}
+		if err = s.logTenderChange(ctx, models.AttachTenderAction, updatedTender); err != nil {
+			return err
+		}
+		return s.events.PublishTender(ctx, events.TenderEdited, updatedTender)
+	})
+	if err != nil {
+		s.Attachments.discard(ctx, key(attachment.ID))
+		return nil, nil, err
+	}
+	return attachment, updatedTender, nil
+}
+
+// DeleteTenderAttachment удаляет вложение тендера. Содержимое вложения остаётся в хранилище,
+// чтобы его можно было восстановить откатом к версии, в которой вложение было.
+// Ненулевая expectedVersion должна совпадать с текущей версией тендера.
+func (s *TenderService) DeleteTenderAttachment(ctx context.Context, tenderId, attachmentId string, expectedVersion int) (*models.Tender, error) {
+	if err := s.checkTenderEdit(ctx, tenderId); err != nil {
+		return nil, err
+	}
+
+	var updatedTender *models.Tender
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTender, err = s.Repo.DeleteTenderAttachment(ctx, tenderId, attachmentId, expectedVersion)
+		if err != nil {
+			return attachmentNotFound(err)
+		}
+		if err = s.logTenderChange(ctx, models.AttachTenderAction, updatedTender); err != nil {
+			return err
+		}
+		return s.events.PublishTender(ctx, events.TenderEdited, updatedTender)
+	})
+	return updatedTender, err
+}
+
+// GetBidAttachments получает список вложений предложения.
+func (s *BidService) GetBidAttachments(ctx context.Context, bidId string) ([]models.Attachment, error) {
+	if _, _, err := s.authorizeBid(ctx, bidId, authz.BidRead); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetBidAttachments(ctx, bidId)
+}
+
+// GetBidAttachmentContent получает вложение предложения и открывает его содержимое. Содержимое закрывает вызывающий.
+func (s *BidService) GetBidAttachmentContent(ctx context.Context, bidId, attachmentId string) (*models.Attachment, io.ReadCloser, error) {
+	if _, _, err := s.authorizeBid(ctx, bidId, authz.BidRead); err != nil {
+		return nil, nil, err
+	}
+	attachment, err := s.Repo.GetBidAttachment(ctx, bidId, attachmentId)
+	if err != nil {
+		return nil, nil, attachmentNotFound(err)
+	}
+	content, err := s.Attachments.open(ctx, bidAttachmentKey(bidId, attachment.ID))
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// CreateBidAttachment загружает вложение предложения. Изменение вложений создаёт новую версию предложения,
+// поэтому ненулевая expectedVersion должна совпадать с текущей версией предложения.
+func (s *BidService) CreateBidAttachment(ctx context.Context, bidId string, expectedVersion int, upload models.AttachmentUpload) (*models.Attachment, *models.Bid, error) {
+	if _, _, err := s.authorizeBid(ctx, bidId, authz.BidEdit); err != nil {
+		return nil, nil, err
+	}
+
+	key := func(attachmentId string) string { return bidAttachmentKey(bidId, attachmentId) }
+	attachment, err := s.Attachments.save(ctx, key, upload, auth.Username(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updatedBid *models.Bid
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedBid, err = s.Repo.CreateBidAttachment(ctx, bidId, expectedVersion, *attachment)
+		if err != nil {
+			return err
+		}
+		return s.logBidChange(ctx, models.AttachBidAction, updatedBid)
+	})
+	if err != nil {
+		s.Attachments.discard(ctx, key(attachment.ID))
+		return nil, nil, err
+	}
+	return attachment, updatedBid, nil
+}
+
+// DeleteBidAttachment удаляет вложение предложения. Содержимое вложения остаётся в хранилище,
+// чтобы его можно было восстановить откатом к версии, в которой вложение было.
+// Ненулевая expectedVersion должна совпадать с текущей версией предложения.
+func (s *BidService) DeleteBidAttachment(ctx context.Context, bidId, attachmentId string, expectedVersion int) (*models.Bid, error) {
+	if _, _, err := s.authorizeBid(ctx, bidId, authz.BidEdit); err != nil {
+		return nil, err
+	}
+
+	var updatedBid *models.Bid
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedBid, err = s.Repo.DeleteBidAttachment(ctx, bidId, attachmentId, expectedVersion)
+		if err != nil {
+			return attachmentNotFound(err)
+		}
+		return s.logBidChange(ctx, models.AttachBidAction, updatedBid)
+	})
+	return updatedBid, err
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+	"github.com/senyabanana/tender-service/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuctionService - сервис понижающих аукционов по тендерам.
+type AuctionService struct {
+	Repo   repository.AuctionRepository
+	Bids   repository.BidRepository
+	dbPool *pgxpool.Pool
+	uow    *db.UnitOfWork
+	policy authz.Policy
+	audit  *AuditLogger
+}
+
+// NewAuctionService создаёт новый экземпляр AuctionService.
+func NewAuctionService(repo repository.AuctionRepository, bids repository.BidRepository, dbPool *pgxpool.Pool, uow *db.UnitOfWork, policy authz.Policy, audit *AuditLogger) *AuctionService {
+	return &AuctionService{Repo: repo, Bids: bids, dbPool: dbPool, uow: uow, policy: policy, audit: audit}
+}
+
+// GetAuction получает настройки и состояние аукциона по тендеру.
+func (s *AuctionService) GetAuction(ctx context.Context, tenderId string) (*models.Auction, error) {
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return nil, err
+	}
+	return s.getAuction(ctx, tenderId)
+}
+
+// SaveAuction включает для тендера режим аукциона или меняет его валюту и расписание раундов
+// до начала первого раунда. Аукцион недоступен для закрытых тендеров и тендеров в закрытом режиме.
+func (s *AuctionService) SaveAuction(ctx context.Context, tenderId string, auctionReq models.AuctionRequest) (*models.Auction, error) {
+	now := time.Now().UTC()
+	if err := models.ValidateAuction(auctionReq, now); err != nil {
+		return nil, err
+	}
+	if _, err := callerUsername(ctx); err != nil {
+		return nil, err
+	}
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderEdit, authz.TenderResource(tenderId),
+		"you are not authorized to edit this tender")
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := utils.GetTenderById(ctx, s.dbPool, tenderId)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
+	}
+	if tender.Status == models.ClosedTender {
+		return nil, models.NewErrorResponse(http.StatusConflict, "auction cannot be set up for a closed tender")
+	}
+	if tender.Sealed {
+		return nil, models.NewErrorResponse(http.StatusConflict, "auction is not available for sealed tenders")
+	}
+	lastRound := auctionReq.Rounds[len(auctionReq.Rounds)-1]
+	if tender.SubmissionDeadline != nil && lastRound.EndsAt.After(*tender.SubmissionDeadline) {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "auction rounds must end before the submission deadline")
+	}
+
+	var auction *models.Auction
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		auction, err = s.Repo.SaveAuction(ctx, tenderId, auctionReq, now)
+		if err != nil {
+			return err
+		}
+		return s.audit.Log(ctx, models.TenderEntity, tenderId, tenderId, models.AuctionTenderAction, nil, auction)
+	})
+	return auction, err
+}
+
+// Rebid снижает цену опубликованного предложения в идущем раунде аукциона и создаёт новую версию предложения.
+// Новая цена должна быть ниже предыдущей цены предложения. Ненулевая expectedVersion должна совпадать
+// с текущей версией предложения.
+func (s *AuctionService) Rebid(ctx context.Context, bidId string, rebidReq models.RebidRequest) (*models.Bid, error) {
+	if !models.ValidPriceAmount(rebidReq.PriceAmount) {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid priceAmount, must be a non-negative number less than 1e16 with at most 2 decimal places")
+	}
+	if _, err := callerIdentity(ctx); err != nil {
+		return nil, err
+	}
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.BidEdit, authz.BidResource(bidId),
+		"you are not authorized to edit this bid")
+	if err != nil {
+		return nil, err
+	}
+	bid, err := utils.GetBidById(ctx, s.dbPool, bidId)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusNotFound, "bid not found")
+	}
+	tender, err := utils.GetTenderById(ctx, s.dbPool, bid.TenderId)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
+	}
+	if tender.Status != models.PublishedTender {
+		return nil, models.NewErrorResponse(http.StatusConflict, "tender is not published")
+	}
+	auction, err := s.getAuction(ctx, bid.TenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedBid *models.Bid
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		now := time.Now().UTC()
+		round, err := s.Repo.LockActiveRound(ctx, bid.TenderId, now)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.NewErrorResponse(http.StatusConflict, "no auction round is in progress for this tender")
+			}
+			return err
+		}
+
+		updatedBid, err = s.Bids.LowerBidPrice(ctx, bidId, auction.Currency, rebidReq.PriceAmount, rebidReq.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+		previousBid, err := s.Bids.GetBidVersion(ctx, bidId, updatedBid.Version-1)
+		if err != nil {
+			return err
+		}
+
+		rebid := models.AuctionRebid{
+			BidID:         bidId,
+			RoundNumber:   round.Number,
+			PreviousPrice: *previousBid.PriceAmount,
+			PriceAmount:   rebidReq.PriceAmount,
+			CreatedAt:     now,
+		}
+		if err = s.Repo.SaveRebid(ctx, bid.TenderId, rebid); err != nil {
+			return err
+		}
+		return s.audit.Log(ctx, models.BidEntity, bidId, bid.TenderId, models.RebidBidAction, previousBid, updatedBid)
+	})
+	return updatedBid, err
+}
+
+// GetLeaderboard возвращает таблицу лидеров аукциона: опубликованные предложения по возрастанию цены.
+// Предложения с равной ценой делят место. Названия чужих предложений, не видимых пользователю, скрываются.
+func (s *AuctionService) GetLeaderboard(ctx context.Context, tenderId string) (*models.Leaderboard, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return nil, err
+	}
+	auction, err := s.getAuction(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.Repo.GetLeaderboard(ctx, tenderId, auction.Currency, identity.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if i > 0 && entries[i].PriceAmount == entries[i-1].PriceAmount {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+
+	return &models.Leaderboard{
+		TenderID:     tenderId,
+		Currency:     auction.Currency,
+		CurrentRound: auction.ActiveRound(time.Now()),
+		WinnerBidID:  auction.WinnerBidID,
+		Entries:      entries,
+	}, nil
+}
+
+// CloseEndedRounds закрывает не более limit окончившихся раундов аукционов от имени системного пользователя.
+// После закрытия последнего раунда аукцион завершается, и победителем становится предложение с наименьшей ценой:
+// в обычной процедуре принятия решений согласовать можно только его.
+func (s *AuctionService) CloseEndedRounds(ctx context.Context, limit int) ([]models.Auction, error) {
+	ctx = auth.WithIdentity(ctx, auth.Identity{Username: SystemActor})
+
+	var auctions []models.Auction
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		now := time.Now().UTC()
+		tenderIds, err := s.Repo.CloseEndedRounds(ctx, now, limit)
+		if err != nil {
+			return err
+		}
+		for _, tenderId := range tenderIds {
+			auction, err := s.Repo.FinishAuction(ctx, tenderId, now)
+			if errors.Is(err, pgx.ErrNoRows) {
+				auction, err = s.Repo.GetAuction(ctx, tenderId)
+			}
+			if err != nil {
+				return err
+			}
+			if err = s.audit.Log(ctx, models.TenderEntity, tenderId, tenderId, models.RoundTenderAction, nil, auction); err != nil {
+				return err
+			}
+			auctions = append(auctions, *auction)
+		}
+		return nil
+	})
+	return auctions, err
+}
+
+// getAuction получает аукцион по тендеру, возвращая 404, если аукцион не настроен.
+func (s *AuctionService) getAuction(ctx context.Context, tenderId string) (*models.Auction, error) {
+	auction, err := s.Repo.GetAuction(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.NewErrorResponse(http.StatusNotFound, "auction is not set up for this tender")
+		}
+		return nil, err
+	}
+	return auction, nil
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// SystemActor - имя, от которого в журнал аудита записываются действия фоновых задач.
+const SystemActor = "system"
+
+// AuditLogger записывает изменяющие состояние операции в журнал аудита.
+type AuditLogger struct {
+	Repo repository.AuditRepository
+}
+
+// NewAuditLogger создаёт новый экземпляр AuditLogger.
+func NewAuditLogger(repo repository.AuditRepository) *AuditLogger {
+	return &AuditLogger{Repo: repo}
+}
+
+// Log записывает в журнал действие вызывающего пользователя над объектом тендера tenderId
+// вместе с состоянием объекта до и после изменения. Пустое состояние передаётся как nil.
+// Метод следует вызывать в той же транзакции, что и само изменение: если вызывающий пользователь
+// не определён, запись не создаётся, а возвращаемая ошибка 401 откатывает изменение.
+func (l *AuditLogger) Log(ctx context.Context, entityType models.AuditEntityType, entityId, tenderId string, action models.AuditAction, before, after interface{}) error {
+	actor := auth.Username(ctx)
+	if actor == "" {
+		return models.NewErrorResponse(http.StatusUnauthorized, "authentication required")
+	}
+
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+
+	event := models.AuditEvent{
+		ID:         uuid.New().String(),
+		Actor:      actor,
+		EntityType: entityType,
+		EntityID:   entityId,
+		Action:     action,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		CreatedAt:  time.Now().UTC(),
+	}
+	return l.Repo.SaveEvent(ctx, event, tenderId)
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+	"github.com/senyabanana/tender-service/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+type AuditService struct {
+	Repo   repository.AuditRepository
+	policy authz.Policy
+}
+
+// NewAuditService создаёт новый экземпляр AuditService.
+func NewAuditService(repo repository.AuditRepository, policy authz.Policy) *AuditService {
+	return &AuditService{Repo: repo, policy: policy}
+}
+
+// GetEvents получает записи журнала аудита за период from-to. Журнал доступен только ответственным за организации,
+// каждый из них видит события тендеров и предложений своих организаций.
+func (s *AuditService) GetEvents(ctx context.Context, entityId, actor string, from, to *time.Time, limitStr, offsetStr string) ([]models.AuditEvent, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, err.Error())
+	}
+	if entityId != "" {
+		if _, err = uuid.Parse(entityId); err != nil {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid entityId parameter")
+		}
+	}
+
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.AuditRead, authz.AuditResource(),
+		"audit log is available only to organization responsibles")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Repo.GetEvents(ctx, models.AuditFilter{
+		EntityID: entityId,
+		Actor:    actor,
+		From:     from,
+		To:       to,
+		ViewerID: identity.UserID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AuthService struct {
+	Issuer *auth.TokenIssuer
+	dbPool *pgxpool.Pool
+}
+
+// NewAuthService создаёт новый экземпляр AuthService.
+func NewAuthService(issuer *auth.TokenIssuer, dbPool *pgxpool.Pool) *AuthService {
+	return &AuthService{Issuer: issuer, dbPool: dbPool}
+}
+
+// IssueToken проверяет учётные данные пользователя и выпускает для него токен доступа.
+func (s *AuthService) IssueToken(ctx context.Context, tokenReq models.TokenRequest) (*models.TokenResponse, error) {
+	if tokenReq.Username == "" || tokenReq.Password == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required fields: username or password")
+	}
+
+	userId, passwordHash, err := utils.GetUserCredentials(ctx, s.dbPool, tokenReq.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.NewErrorResponse(http.StatusUnauthorized, "invalid username or password")
+		}
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user credentials")
+	}
+	if passwordHash == nil {
+		return nil, models.NewErrorResponse(http.StatusUnauthorized, "password is not set for this user")
+	}
+	if err = bcrypt.CompareHashAndPassword([]byte(*passwordHash), []byte(tokenReq.Password)); err != nil {
+		return nil, models.NewErrorResponse(http.StatusUnauthorized, "invalid username or password")
+	}
+
+	token, expiresAt, err := s.Issuer.Issue(auth.Identity{UserID: userId, Username: tokenReq.Username})
+	if err != nil {
+		return nil, err
+	}
+	return &models.TokenResponse{AccessToken: token, TokenType: "Bearer", ExpiresAt: expiresAt}, nil
+}
+
+// callerUsername возвращает username вызывающего пользователя или ошибку, если запрос анонимный.
+func callerUsername(ctx context.Context) (string, error) {
+	username := auth.Username(ctx)
+	if username == "" {
+		return "", models.NewErrorResponse(http.StatusUnauthorized, "authentication required")
+	}
+	return username, nil
+}
+
+// callerIdentity возвращает данные вызывающего пользователя или ошибку, если запрос анонимный.
+func callerIdentity(ctx context.Context) (auth.Identity, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == "" {
+		return auth.Identity{}, models.NewErrorResponse(http.StatusUnauthorized, "authentication required")
+	}
+	return identity, nil
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/models"
+)
+
+// subjectFromContext возвращает субъект проверки прав для вызывающего пользователя.
+func subjectFromContext(ctx context.Context) authz.Subject {
+	identity, _ := auth.FromContext(ctx)
+	return authz.Subject{UserID: identity.UserID, Username: identity.Username}
+}
+
+// authorize проверяет по политике доступа, может ли пользователь выполнить действие над ресурсом.
+// Отказ возвращается как ошибка 403 с сообщением message, отсутствующий ресурс - как ошибка 404.
+func authorize(ctx context.Context, policy authz.Policy, subject authz.Subject, action authz.Action, resource authz.Resource, message string) error {
+	allowed, err := policy.Can(ctx, subject, action, resource)
+	if err != nil {
+		if errors.Is(err, authz.ErrResourceNotFound) {
+			return models.NewErrorResponse(http.StatusNotFound, fmt.Sprintf("%s not found", resource.Type))
+		}
+		return models.NewErrorResponse(http.StatusInternalServerError, "failed to check user authorization")
+	}
+	if !allowed {
+		return models.NewErrorResponse(http.StatusForbidden, message)
+	}
+	return nil
+}
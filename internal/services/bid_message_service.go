@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/models"
+)
+
+// GetBidMessages получает страницу переписки по предложению и отмечает полученные сообщения прочитанными
+// вызывающим пользователем. Переписка доступна стороне автора предложения и ответственным за организацию
+// тендера в любом статусе предложения, а в закрытом режиме - после вскрытия предложений.
+func (s *BidService) GetBidMessages(ctx context.Context, bidId string, page models.Page) (models.Paged[models.BidMessage], error) {
+	_, identity, err := s.authorizeBid(ctx, bidId, authz.BidNegotiate)
+	if err != nil {
+		return models.Paged[models.BidMessage]{}, err
+	}
+	messages, err := s.Repo.GetBidMessages(ctx, bidId, page)
+	if err != nil {
+		return models.Paged[models.BidMessage]{}, err
+	}
+	if len(messages.Items) > 0 {
+		lastRead := messages.Items[len(messages.Items)-1].CreatedAt
+		if err = s.Repo.MarkBidMessagesRead(ctx, bidId, identity.UserID, lastRead); err != nil {
+			return models.Paged[models.BidMessage]{}, err
+		}
+	}
+	return messages, nil
+}
+
+// SendBidMessage отправляет сообщение в переписку по предложению от стороны вызывающего пользователя.
+// Отправка сообщения отмечает прочитанными все более ранние сообщения переписки.
+func (s *BidService) SendBidMessage(ctx context.Context, bidId string, messageReq models.BidMessageRequest) (*models.BidMessage, error) {
+	if err := models.ValidateBidMessage(messageReq); err != nil {
+		return nil, err
+	}
+	bid, identity, err := s.authorizeBid(ctx, bidId, authz.BidNegotiate)
+	if err != nil {
+		return nil, err
+	}
+	side, err := s.bidMessageSide(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	var message *models.BidMessage
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		message, err = s.Repo.CreateBidMessage(ctx, identity.UserID, models.BidMessage{
+			BidID:      bidId,
+			AuthorSide: side,
+			BidVersion: bid.Version,
+			Body:       messageReq.Body,
+		})
+		if err != nil {
+			return err
+		}
+		return s.Repo.MarkBidMessagesRead(ctx, bidId, identity.UserID, message.CreatedAt)
+	})
+	return message, err
+}
+
+// GetUnreadBidMessages получает число непрочитанных вызывающим пользователем сообщений
+// по каждому доступному ему предложению, в переписке по которому такие сообщения есть.
+func (s *BidService) GetUnreadBidMessages(ctx context.Context) ([]models.BidUnreadMessages, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetUnreadBidMessages(ctx, identity.UserID)
+}
+
+// bidMessageSide определяет сторону переписки вызывающего пользователя: сторона предложения,
+// если пользователь может редактировать предложение, иначе - сторона тендера.
+func (s *BidService) bidMessageSide(ctx context.Context, bidId string) (models.BidMessageSide, error) {
+	isBidSide, err := s.policy.Can(ctx, subjectFromContext(ctx), authz.BidEdit, authz.BidResource(bidId))
+	if err != nil {
+		return "", err
+	}
+	if isBidSide {
+		return models.BidSide, nil
+	}
+	return models.TenderSide, nil
+}
@@ -2,29 +2,50 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/events"
 	"github.com/senyabanana/tender-service/internal/models"
 	"github.com/senyabanana/tender-service/internal/repository"
 	"github.com/senyabanana/tender-service/internal/utils"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type BidService struct {
-	Repo   repository.BidRepository
-	dbPool *pgxpool.Pool
+	Repo        repository.BidRepository
+	Tenders     repository.TenderRepository
+	Auctions    repository.AuctionRepository
+	Attachments *AttachmentStorage
+	dbPool      *pgxpool.Pool
+	uow         *db.UnitOfWork
+	policy      authz.Policy
+	audit       *AuditLogger
+	events      *EventPublisher
 }
 
 // NewBidService создает новый экземпляр BidService.
-func NewBidService(repo repository.BidRepository, dbPool *pgxpool.Pool) *BidService {
-	return &BidService{Repo: repo, dbPool: dbPool}
+func NewBidService(repo repository.BidRepository, tenders repository.TenderRepository, auctions repository.AuctionRepository, dbPool *pgxpool.Pool, uow *db.UnitOfWork, policy authz.Policy, audit *AuditLogger, publisher *EventPublisher, attachments *AttachmentStorage) *BidService {
+	return &BidService{Repo: repo, Tenders: tenders, Auctions: auctions, Attachments: attachments, dbPool: dbPool, uow: uow, policy: policy, audit: audit, events: publisher}
 }
 
-// CreateBid создает новое предложение.
+// CreateBid создает новое предложение от имени вызывающего пользователя. Поле authorId из тела запроса
+// заменяется пользователем, определённым по токену или, в режиме совместимости, по параметру username.
 func (s *BidService) CreateBid(ctx context.Context, bidReq models.BidRequest) (*models.Bid, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bidReq.AuthorId = identity.UserID
+
 	if bidReq.Name == "" || bidReq.Description == "" || bidReq.TenderId == "" || bidReq.AuthorType == "" || bidReq.AuthorId == "" {
 		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required fields")
 	}
@@ -33,6 +54,10 @@ func (s *BidService) CreateBid(ctx context.Context, bidReq models.BidRequest) (*
 		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid author type. Must be 'Organization' or 'User'")
 	}
 
+	if err := models.ValidateBidTerms(bidReq.BidTerms); err != nil {
+		return nil, err
+	}
+
 	if bidReq.AuthorType == models.Organization {
 		orgExists, err := utils.CheckUserInAnyOrganization(ctx, s.dbPool, bidReq.AuthorId)
 		if err != nil {
@@ -48,247 +73,461 @@ func (s *BidService) CreateBid(ctx context.Context, bidReq models.BidRequest) (*
 		}
 	}
 
-	tenderExists, err := utils.CheckTenderExists(ctx, s.dbPool, bidReq.TenderId)
-	if !tenderExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
-	}
-	return s.Repo.CreateBid(ctx, bidReq)
-}
-
-// GetUserBid получает список предложений для пользователя.
-func (s *BidService) GetUserBid(ctx context.Context, limitStr, offsetStr, username string) ([]models.Bid, error) {
-	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+	subject := subjectFromContext(ctx)
+	subject.UserID = bidReq.AuthorId
+	err = authorize(ctx, s.policy, subject, authz.BidCreate, authz.TenderResource(bidReq.TenderId),
+		"you are not authorized to create bids for this tender")
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, err.Error())
+		return nil, err
 	}
 
-	if username != "" {
-		userExists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	var bid *models.Bid
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.checkTenderAcceptsBids(ctx, bidReq.TenderId); err != nil {
+			return err
+		}
+		var err error
+		bid, err = s.Repo.CreateBid(ctx, bidReq)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
+			return err
 		}
-		if !userExists {
-			return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+		if err = s.audit.Log(ctx, models.BidEntity, bid.ID, bid.TenderId, models.CreateBidAction, nil, bid); err != nil {
+			return err
 		}
-	}
-	return s.Repo.GetUserBid(ctx, limit, offset, username)
+		return s.events.PublishBid(ctx, events.BidCreated, bid)
+	})
+	return bid, err
 }
 
-// GetTenderBid получает список предложений для тендера.
-func (s *BidService) GetTenderBid(ctx context.Context, username, tenderId, limitStr, offsetStr string) ([]models.Bid, error) {
-	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+// GetUserBid получает страницу списка предложений пользователя.
+func (s *BidService) GetUserBid(ctx context.Context, page models.Page) (models.Paged[models.Bid], error) {
+	username, err := callerUsername(ctx)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, err.Error())
-	}
-
-	if tenderId == "" || username == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameters: username or tenderId")
+		return models.Paged[models.Bid]{}, err
 	}
 
 	userExists, err := utils.CheckUserExists(ctx, s.dbPool, username)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
+		return models.Paged[models.Bid]{}, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
 	}
 	if !userExists {
-		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist or is incorrect")
+		return models.Paged[models.Bid]{}, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
 	}
+	return s.Repo.GetUserBid(ctx, page, username)
+}
 
-	tenderExists, err := utils.CheckTenderExists(ctx, s.dbPool, tenderId)
-	if !tenderExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
+// GetTenderBid получает страницу списка предложений для тендера, видимых вызывающему пользователю,
+// с учётом фильтров и сортировки. Для закрытого тендера до вскрытия предложений вместо списка
+// возвращается SealedBids: число опубликованных предложений и собственные предложения пользователя.
+func (s *BidService) GetTenderBid(ctx context.Context, tenderId string, page models.Page, filter models.BidFilter) (models.Paged[models.Bid], *models.SealedBids, error) {
+	if tenderId == "" {
+		return models.Paged[models.Bid]{}, nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: tenderId")
 	}
-
-	isAuthorized, err := utils.CheckUserAuthorized(ctx, s.dbPool, username, tenderId)
+	identity, err := callerIdentity(ctx)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user authorization")
+		return models.Paged[models.Bid]{}, nil, err
 	}
-	if !isAuthorized {
-		return nil, models.NewErrorResponse(http.StatusForbidden, "user is not authorized to view bids for this tender")
+
+	tender, err := utils.GetTenderById(ctx, s.dbPool, tenderId)
+	if err != nil {
+		return models.Paged[models.Bid]{}, nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
 	}
-	return s.Repo.GetTenderBid(ctx, tenderId, limit, offset)
-}
 
-// GetBidStatus получает статут предложения.
-func (s *BidService) GetBidStatus(ctx context.Context, bidId, username string) (*models.BidStatus, error) {
-	if username == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "username is required")
+	bids, err := s.Repo.GetTenderBid(ctx, tenderId, identity.UserID, filter, page)
+	if err != nil || !tender.BidsSealed(time.Now()) {
+		return bids, nil, err
 	}
 
-	userExists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	count, err := s.Repo.CountTenderBids(ctx, tenderId, models.PublishedBid)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
+		return models.Paged[models.Bid]{}, nil, err
 	}
-	if !userExists {
-		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+	return models.Paged[models.Bid]{}, &models.SealedBids{Sealed: true, BidsOpeningAt: tender.BidsOpeningAt, BidsCount: count, Bids: bids.Items}, nil
+}
+
+// GetBidStatus получает статут предложения.
+func (s *BidService) GetBidStatus(ctx context.Context, bidId string) (*models.BidStatus, error) {
+	bid, _, err := s.authorizeBid(ctx, bidId, authz.BidRead)
+	if err != nil {
+		return nil, err
 	}
-	return s.Repo.GetBidStatus(ctx, bidId)
+	return &bid.Status, nil
 }
 
 // UpdateBidStatus меняет статус предложения.
-func (s *BidService) UpdateBidStatus(ctx context.Context, bidId, status, username string) (*models.Bid, error) {
-	if status == "" || username == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameters: username or status")
+// Ненулевая expectedVersion должна совпадать с текущей версией предложения.
+func (s *BidService) UpdateBidStatus(ctx context.Context, bidId, status string, expectedVersion int) (*models.Bid, error) {
+	if status == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: status")
+	}
+	if _, _, err := s.authorizeBid(ctx, bidId, authz.BidEdit); err != nil {
+		return nil, err
 	}
 
-	if username != "" {
-		userExists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	var updatedBid *models.Bid
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedBid, err = s.Repo.UpdateBidStatus(ctx, bidId, status, expectedVersion)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
+			return err
 		}
-		if !userExists {
-			return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+		if err = s.logBidChange(ctx, models.StatusBidAction, updatedBid); err != nil {
+			return err
 		}
-		isAuthorized, err := utils.CheckUserAuthorizedForBid(ctx, s.dbPool, username, bidId)
+		if updatedBid.Status == models.PublishedBid {
+			if err = s.events.PublishBid(ctx, events.BidPublished, updatedBid); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return updatedBid, err
+}
+
+// EditBid меняет описание и коммерческие условия предложения.
+// Ненулевая expectedVersion должна совпадать с текущей версией предложения.
+func (s *BidService) EditBid(ctx context.Context, bidId string, expectedVersion int, updateFields map[string]interface{}) (*models.Bid, error) {
+	if bidId == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: bidId")
+	}
+	bid, _, err := s.authorizeBid(ctx, bidId, authz.BidEdit)
+	if err != nil {
+		return nil, err
+	}
+	_, priceChanged := updateFields["priceAmount"]
+	_, currencyChanged := updateFields["currency"]
+	if priceChanged || currencyChanged {
+		err = s.checkAuctionNotStarted(ctx, bid.TenderId, "auction for this tender has started, the price can only be lowered with a rebid")
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user authorization")
+			return nil, err
 		}
-		if !isAuthorized {
-			return nil, models.NewErrorResponse(http.StatusForbidden, "user is not authorized to view bids for this tender")
+	}
+
+	var updatedBid *models.Bid
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedBid, err = s.Repo.EditBid(ctx, bidId, expectedVersion, updateFields)
+		if err != nil {
+			return err
 		}
+		return s.logBidChange(ctx, models.EditBidAction, updatedBid)
+	})
+	return updatedBid, err
+}
+
+// SubmitBidDecision отправляет решение ответственного по предложению и возвращает текущие итоги голосования.
+func (s *BidService) SubmitBidDecision(ctx context.Context, bidId, decision string) (*models.BidDecisionResult, error) {
+	if decision == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "decision is required")
+	}
+
+	allowedDecision := map[models.BidDecision]bool{
+		models.ApprovedBid: true,
+		models.RejectedBid: true,
+	}
+	if !allowedDecision[models.BidDecision(decision)] {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid decision, must be either 'Approved' or 'Rejected'")
 	}
 
-	currentBid, err := utils.GetBidById(ctx, s.dbPool, bidId)
+	bid, identity, err := s.authorizeBid(ctx, bidId, authz.BidDecide)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "bid not found")
+		return nil, err
 	}
 
-	allowedStatusTransition := map[models.BidStatus][]models.BidStatus{
-		models.CreatedBid:   {models.PublishedBid, models.CanceledBid},
-		models.PublishedBid: {models.CanceledBid},
-		models.CanceledBid:  {},
+	if err = s.checkAuctionDecision(ctx, bid, models.BidDecision(decision)); err != nil {
+		return nil, err
 	}
 
-	validTransition := allowedStatusTransition[currentBid.Status]
-	if !utils.ContainsBid(validTransition, models.BidStatus(status)) {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid bid status")
+	alreadyDecided, err := utils.CheckUserDecidedOnBid(ctx, s.dbPool, identity.UserID, bidId)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check previous decisions")
 	}
-	return s.Repo.UpdateBidStatus(ctx, bidId, status)
+	if alreadyDecided {
+		return nil, models.NewErrorResponse(http.StatusConflict, "you have already submitted a decision for this bid")
+	}
+
+	var result *models.BidDecisionResult
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.Repo.SubmitBidDecision(ctx, bidId, identity.UserID, decision)
+		if err != nil {
+			return err
+		}
+		if err = s.audit.Log(ctx, models.BidEntity, bidId, bid.TenderId, models.DecisionBidAction, bid, result); err != nil {
+			return err
+		}
+		if err = s.events.PublishBid(ctx, events.BidDecided, &result.Bid); err != nil {
+			return err
+		}
+		if result.Status == models.BidStatus(models.ApprovedBid) {
+			return s.closeDecidedTender(ctx, bid.TenderId)
+		}
+		return nil
+	})
+	return result, err
 }
 
-// EditBid меняет описание предложения.
-func (s *BidService) EditBid(ctx context.Context, bidId, username string, updateFields map[string]interface{}) (*models.Bid, error) {
-	if username == "" || bidId == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: bidId or username")
+// closeDecidedTender закрывает тендер, по которому одобрено предложение, если он ещё не закрыт.
+// Закрытие создаёт новую версию тендера, записывается в журнал аудита и публикуется событием tender.closed.
+func (s *BidService) closeDecidedTender(ctx context.Context, tenderId string) error {
+	closedTender, err := s.Tenders.CloseTender(ctx, tenderId)
+	if err != nil || closedTender == nil {
+		return err
+	}
+	if err = logTenderVersionChange(ctx, s.Tenders, s.audit, models.StatusTenderAction, closedTender); err != nil {
+		return err
 	}
+	return s.events.PublishTender(ctx, events.TenderClosed, closedTender)
+}
 
-	bidExists, err := utils.CheckBidExists(ctx, s.dbPool, bidId)
-	if !bidExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "bid not found")
+// SubmitBidFeedback отправляет отзыв на предложение.
+func (s *BidService) SubmitBidFeedback(ctx context.Context, review models.BidReview, bidId, bidFeedback string) (*models.Bid, error) {
+	if bidFeedback == "" || bidId == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "bidFeedback and bidId are required")
+	}
+	bid, _, err := s.authorizeBid(ctx, bidId, authz.ReviewCreate)
+	if err != nil {
+		return nil, err
 	}
 
-	if username != "" {
-		userExists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	var updatedBid *models.Bid
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedBid, err = s.Repo.SubmitBidFeedback(ctx, review, bidId)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
+			return err
 		}
-		if !userExists {
-			return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+		if err = s.audit.Log(ctx, models.BidEntity, bidId, bid.TenderId, models.FeedbackBidAction, nil, review); err != nil {
+			return err
 		}
+		return s.events.PublishBid(ctx, events.BidFeedback, updatedBid)
+	})
+	return updatedBid, err
+}
+
+// RollbackBid откатывает версию предложения.
+// Ненулевая expectedVersion должна совпадать с текущей версией предложения.
+func (s *BidService) RollbackBid(ctx context.Context, bidId, versionStr string, expectedVersion int) (*models.Bid, error) {
+	if bidId == "" || versionStr == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: bidId or version")
 	}
-	return s.Repo.EditBid(ctx, bidId, updateFields)
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid version number")
+	}
+	bid, _, err := s.authorizeBid(ctx, bidId, authz.BidRollback)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkAuctionNotStarted(ctx, bid.TenderId, "auction for this tender has started, bids cannot be rolled back"); err != nil {
+		return nil, err
+	}
+
+	var updatedBid *models.Bid
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedBid, err = s.Repo.RollbackBid(ctx, bidId, version, expectedVersion)
+		if err != nil {
+			return err
+		}
+		return s.logBidChange(ctx, models.RollbackBidAction, updatedBid)
+	})
+	return updatedBid, err
 }
 
-// SubmitBidDecision отправляет решение по предложению.
-func (s *BidService) SubmitBidDecision(ctx context.Context, bidId, username, decision string) (*models.Bid, error) {
-	if decision == "" || username == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "decision and username are required")
+// GetBidReviews получает страницу списка отзывов на предложения автора по тендеру.
+// Отзывы доступны только ответственным за организацию тендера.
+func (s *BidService) GetBidReviews(ctx context.Context, tenderId, authorUsername string, page models.Page) (models.Paged[models.BidReview], error) {
+	if tenderId == "" || authorUsername == "" {
+		return models.Paged[models.BidReview]{}, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameters: tenderId or authorUsername")
+	}
+	requesterUsername, err := callerUsername(ctx)
+	if err != nil {
+		return models.Paged[models.BidReview]{}, err
 	}
 
-	allowedDecision := map[models.BidDecision]bool{
-		models.ApprovedBid: true,
-		models.RejectedBid: true,
+	authorExists, err := utils.CheckUserExists(ctx, s.dbPool, authorUsername)
+	if err != nil {
+		return models.Paged[models.BidReview]{}, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
 	}
-	if !allowedDecision[models.BidDecision(decision)] {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid decision, must be either 'Approved' or 'Rejected'")
+	if !authorExists {
+		return models.Paged[models.BidReview]{}, models.NewErrorResponse(http.StatusUnauthorized, "author does not exist")
 	}
 
-	userExists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.ReviewRead, authz.TenderResource(tenderId),
+		"user is not authorized to view bid reviews for this tender")
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
+		return models.Paged[models.BidReview]{}, err
 	}
-	if !userExists {
-		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+
+	tender, err := utils.GetTenderById(ctx, s.dbPool, tenderId)
+	if err != nil {
+		return models.Paged[models.BidReview]{}, models.NewErrorResponse(http.StatusNotFound, "tender not found")
 	}
-	return s.Repo.SubmitBidDecision(ctx, bidId, decision)
+	if tender.BidsSealed(time.Now()) {
+		return models.Paged[models.BidReview]{}, models.NewErrorResponse(http.StatusForbidden, "bids of this tender are sealed until opening")
+	}
+
+	return s.Repo.GetBidReviews(ctx, tenderId, authorUsername, requesterUsername, page)
 }
 
-// SubmitBidFeedback отправляет отзыв на предложение.
-func (s *BidService) SubmitBidFeedback(ctx context.Context, review models.BidReview, bidId, bidFeedback, username string) (*models.Bid, error) {
-	if bidFeedback == "" || username == "" || bidId == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "bidFeedback, username and bidId are required")
+// GetBidVersions получает список всех версий предложения.
+func (s *BidService) GetBidVersions(ctx context.Context, bidId string) ([]models.Bid, error) {
+	if err := s.checkBidHistoryAccess(ctx, bidId); err != nil {
+		return nil, err
 	}
+	return s.Repo.GetBidVersions(ctx, bidId)
+}
 
-	bidExists, err := utils.CheckBidExists(ctx, s.dbPool, bidId)
-	if !bidExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "bid not found")
+// GetBidVersion получает снимок предложения указанной версии.
+func (s *BidService) GetBidVersion(ctx context.Context, bidId, versionStr string) (*models.Bid, error) {
+	version, err := parseVersion("version", versionStr)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkBidHistoryAccess(ctx, bidId); err != nil {
+		return nil, err
 	}
+	return s.getBidVersion(ctx, bidId, version)
+}
 
-	userExists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+// DiffBidVersions сравнивает две версии предложения по полям.
+func (s *BidService) DiffBidVersions(ctx context.Context, bidId, fromStr, toStr string) (*models.VersionDiff, error) {
+	from, err := parseVersion("from", fromStr)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
+		return nil, err
 	}
-	if !userExists {
-		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+	to, err := parseVersion("to", toStr)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkBidHistoryAccess(ctx, bidId); err != nil {
+		return nil, err
 	}
 
-	return s.Repo.SubmitBidFeedback(ctx, review, bidId)
+	fromBid, err := s.getBidVersion(ctx, bidId, from)
+	if err != nil {
+		return nil, err
+	}
+	toBid, err := s.getBidVersion(ctx, bidId, to)
+	if err != nil {
+		return nil, err
+	}
+	return &models.VersionDiff{From: from, To: to, Changes: diffBids(fromBid, toBid)}, nil
 }
 
-// RollbackBid откатывает версию тендера.
-func (s *BidService) RollbackBid(ctx context.Context, bidId, username, versionStr string) (*models.Bid, error) {
-	version, err := strconv.Atoi(versionStr)
+// getBidVersion получает снимок предложения и возвращает 404, если такой версии нет.
+func (s *BidService) getBidVersion(ctx context.Context, bidId string, version int) (*models.Bid, error) {
+	bid, err := s.Repo.GetBidVersion(ctx, bidId, version)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid version number")
-	}
-	bidExists, err := utils.CheckBidExists(ctx, s.dbPool, bidId)
-	if !bidExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "bid not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.NewErrorResponse(http.StatusNotFound, fmt.Sprintf("version %d not found", version))
+		}
+		return nil, err
 	}
+	return bid, nil
+}
 
-	if username == "" || bidId == "" || versionStr == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: bidId or username or version")
+// checkBidHistoryAccess проверяет, что вызывающий пользователь может просматривать историю предложения.
+func (s *BidService) checkBidHistoryAccess(ctx context.Context, bidId string) error {
+	_, _, err := s.authorizeBid(ctx, bidId, authz.BidHistoryRead)
+	return err
+}
+
+// authorizeBid получает предложение и проверяет по политике доступа,
+// может ли вызывающий пользователь выполнить над ним действие.
+func (s *BidService) authorizeBid(ctx context.Context, bidId string, action authz.Action) (*models.Bid, auth.Identity, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, identity, err
 	}
 
-	userExists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	subject := authz.Subject{UserID: identity.UserID, Username: identity.Username}
+	allowed, err := s.policy.Can(ctx, subject, action, authz.BidResource(bidId))
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user existence")
+		if errors.Is(err, authz.ErrResourceNotFound) {
+			return nil, identity, models.NewErrorResponse(http.StatusNotFound, "bid not found")
+		}
+		return nil, identity, models.NewErrorResponse(http.StatusInternalServerError, "failed to check user authorization")
 	}
-	if !userExists {
-		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+	if !allowed {
+		canView, err := s.policy.Can(ctx, subject, authz.BidRead, authz.BidResource(bidId))
+		if err != nil || !canView {
+			return nil, identity, models.NewErrorResponse(http.StatusForbidden, "you are not authorized to view this bid")
+		}
+		return nil, identity, models.NewErrorResponse(http.StatusForbidden, "you are not authorized to perform this action on the bid")
 	}
-	return s.Repo.RollbackBid(ctx, bidId, version)
-}
 
-// GetBidReviews получает список отзывов на предложение.
-func (s *BidService) GetBidReviews(ctx context.Context, tenderId, authorUsername, requesterUsername, limitStr, offsetStr string) ([]models.BidReview, error) {
-	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+	bid, err := utils.GetBidById(ctx, s.dbPool, bidId)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, err.Error())
+		return nil, identity, models.NewErrorResponse(http.StatusNotFound, "bid not found")
 	}
+	return bid, identity, nil
+}
 
-	if tenderId == "" || authorUsername == "" || requesterUsername == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameters: tenderId, authorUsername or requesterUsername")
+// checkTenderAcceptsBids проверяет, что тендер опубликован, срок подачи предложений не истёк и аукцион
+// не начался. Тендер блокируется до конца транзакции, чтобы его не закрыли, пока создаётся предложение,
+// поэтому метод следует вызывать внутри транзакции.
+func (s *BidService) checkTenderAcceptsBids(ctx context.Context, tenderId string) error {
+	tender, err := s.Tenders.ShareLockTender(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.NewErrorResponse(http.StatusNotFound, "tender not found")
+		}
+		return err
 	}
-
-	tenderExists, err := utils.CheckTenderExists(ctx, s.dbPool, tenderId)
-	if !tenderExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
+	if tender.Status != models.PublishedTender {
+		return models.NewErrorResponse(http.StatusBadRequest, "bids can only be submitted to published tenders")
 	}
+	if tender.SubmissionDeadline != nil && !time.Now().Before(*tender.SubmissionDeadline) {
+		return models.NewErrorResponse(http.StatusBadRequest, "submission deadline for this tender has passed")
+	}
+	return s.checkAuctionNotStarted(ctx, tenderId, "auction for this tender has already started, new bids are not accepted")
+}
 
-	requesterExists, err := utils.CheckUserExists(ctx, s.dbPool, requesterUsername)
-	if !requesterExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusUnauthorized, "requester does not exist")
+// tenderAuction получает аукцион по тендеру или nil, если тендер проводится без аукциона.
+func (s *BidService) tenderAuction(ctx context.Context, tenderId string) (*models.Auction, error) {
+	auction, err := s.Auctions.GetAuction(ctx, tenderId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
 	}
+	return auction, err
+}
 
-	authorExists, err := utils.CheckUserExists(ctx, s.dbPool, authorUsername)
-	if !authorExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusUnauthorized, "author does not exist")
+// checkAuctionNotStarted возвращает 409 с сообщением msg, если по тендеру идёт или завершён аукцион.
+func (s *BidService) checkAuctionNotStarted(ctx context.Context, tenderId, msg string) error {
+	auction, err := s.tenderAuction(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+	if auction != nil && auction.Started(time.Now()) {
+		return models.NewErrorResponse(http.StatusConflict, msg)
 	}
+	return nil
+}
 
-	isAuthorized, err := utils.CheckUserAuthorized(ctx, s.dbPool, requesterUsername, tenderId)
-	if !isAuthorized && err != nil {
-		return nil, models.NewErrorResponse(http.StatusForbidden, "user is not authorized to view bid reviews for this tender")
+// checkAuctionDecision проверяет, что решение по предложению тендера с аукционом принимается после его
+// завершения, и согласовать можно только предложение-победителя.
+func (s *BidService) checkAuctionDecision(ctx context.Context, bid *models.Bid, decision models.BidDecision) error {
+	auction, err := s.tenderAuction(ctx, bid.TenderId)
+	if err != nil || auction == nil {
+		return err
+	}
+	if auction.FinishedAt == nil {
+		return models.NewErrorResponse(http.StatusConflict, "auction for this tender is not finished yet")
 	}
+	if decision == models.ApprovedBid && (auction.WinnerBidID == nil || *auction.WinnerBidID != bid.ID) {
+		return models.NewErrorResponse(http.StatusConflict, "only the winning bid of the auction can be approved")
+	}
+	return nil
+}
 
-	return s.Repo.GetBidReviews(ctx, tenderId, authorUsername, requesterUsername, limit, offset)
+// logBidChange записывает в журнал аудита изменение предложения, создавшее новую версию.
+// Состояние до изменения берётся из снимка предыдущей версии в истории.
+func (s *BidService) logBidChange(ctx context.Context, action models.AuditAction, updatedBid *models.Bid) error {
+	previousBid, err := s.Repo.GetBidVersion(ctx, updatedBid.ID, updatedBid.Version-1)
+	if err != nil {
+		return err
+	}
+	return s.audit.Log(ctx, models.BidEntity, updatedBid.ID, updatedBid.TenderId, action, previousBid, updatedBid)
 }
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+	"github.com/senyabanana/tender-service/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minPasswordLength - минимальная длина пароля сотрудника.
+const minPasswordLength = 8
+
+type EmployeeService struct {
+	Repo   repository.EmployeeRepository
+	dbPool *pgxpool.Pool
+	uow    *db.UnitOfWork
+}
+
+// NewEmployeeService создаёт новый экземпляр EmployeeService.
+func NewEmployeeService(repo repository.EmployeeRepository, dbPool *pgxpool.Pool, uow *db.UnitOfWork) *EmployeeService {
+	return &EmployeeService{Repo: repo, dbPool: dbPool, uow: uow}
+}
+
+// CreateEmployee регистрирует нового сотрудника. Первого сотрудника может зарегистрировать любой клиент,
+// дальше регистрировать сотрудников могут только ответственные за организации. Пароль сохраняется
+// в виде bcrypt-хэша.
+func (s *EmployeeService) CreateEmployee(ctx context.Context, employeeReq models.EmployeeRequest) (*models.Employee, error) {
+	if employeeReq.Username == "" || employeeReq.Password == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required fields: username or password")
+	}
+	if len(employeeReq.Username) > 50 || len(employeeReq.FirstName) > 50 || len(employeeReq.LastName) > 50 {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "username, firstName and lastName must not exceed 50 characters")
+	}
+
+	passwordHash, err := hashPassword(employeeReq.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var employee *models.Employee
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		count, err := s.Repo.LockEmployees(ctx)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			if err = s.checkCanRegister(ctx); err != nil {
+				return err
+			}
+		}
+		employee, err = s.Repo.CreateEmployee(ctx, employeeReq, &passwordHash)
+		return err
+	})
+	return employee, err
+}
+
+// checkCanRegister проверяет, что вызывающий пользователь может регистрировать сотрудников:
+// он аутентифицирован и является ответственным за какую-либо организацию.
+func (s *EmployeeService) checkCanRegister(ctx context.Context) error {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+	responsible, err := utils.CheckUserInAnyOrganization(ctx, s.dbPool, identity.UserID)
+	if err != nil {
+		return models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+	}
+	if !responsible {
+		return models.NewErrorResponse(http.StatusForbidden, "only organization responsibles can register employees")
+	}
+	return nil
+}
+
+// GetEmployee получает сотрудника по id.
+func (s *EmployeeService) GetEmployee(ctx context.Context, employeeId string) (*models.Employee, error) {
+	if _, err := callerIdentity(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(employeeId); err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid employeeId")
+	}
+
+	employee, err := s.Repo.GetEmployee(ctx, employeeId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.NewErrorResponse(http.StatusNotFound, "employee not found")
+		}
+		return nil, err
+	}
+	return employee, nil
+}
+
+// GetEmployees получает список сотрудников.
+func (s *EmployeeService) GetEmployees(ctx context.Context, limitStr, offsetStr string) ([]models.Employee, error) {
+	if _, err := callerIdentity(ctx); err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, err.Error())
+	}
+	return s.Repo.GetEmployees(ctx, limit, offset)
+}
+
+// UpdateEmployee меняет данные сотрудника. Сотрудник может менять только собственные данные.
+func (s *EmployeeService) UpdateEmployee(ctx context.Context, employeeId string, updateFields map[string]interface{}) (*models.Employee, error) {
+	if err := s.checkSelf(ctx, employeeId); err != nil {
+		return nil, err
+	}
+	if _, ok := updateFields["username"]; ok {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "username cannot be changed")
+	}
+
+	validFields := make(map[string]interface{})
+	for _, field := range []string{"firstName", "lastName"} {
+		if value, ok := updateFields[field]; ok {
+			name, ok := value.(string)
+			if !ok || len(name) > 50 {
+				return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid "+field+", must be a string up to 50 characters")
+			}
+			validFields[field] = name
+		}
+	}
+	if value, ok := updateFields["password"]; ok {
+		password, ok := value.(string)
+		if !ok {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid password")
+		}
+		passwordHash, err := hashPassword(password)
+		if err != nil {
+			return nil, err
+		}
+		validFields["passwordHash"] = passwordHash
+	}
+	if len(validFields) == 0 {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "No valid fields to update")
+	}
+
+	return s.Repo.UpdateEmployee(ctx, employeeId, validFields)
+}
+
+// DeleteEmployee удаляет сотрудника. Сотрудник может удалить только собственную учётную запись.
+func (s *EmployeeService) DeleteEmployee(ctx context.Context, employeeId string) error {
+	if err := s.checkSelf(ctx, employeeId); err != nil {
+		return err
+	}
+	return s.Repo.DeleteEmployee(ctx, employeeId)
+}
+
+// checkSelf проверяет, что сотрудник существует и совпадает с вызывающим пользователем.
+func (s *EmployeeService) checkSelf(ctx context.Context, employeeId string) error {
+	if _, err := s.GetEmployee(ctx, employeeId); err != nil {
+		return err
+	}
+	identity, _ := callerIdentity(ctx)
+	if identity.UserID != employeeId {
+		return models.NewErrorResponse(http.StatusForbidden, "you can only modify your own account")
+	}
+	return nil
+}
+
+// hashPassword проверяет длину пароля и возвращает его bcrypt-хэш.
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", models.NewErrorResponse(http.StatusBadRequest, "password must be at least 8 characters long")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
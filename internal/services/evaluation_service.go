@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+	"github.com/senyabanana/tender-service/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EvaluationService - сервис критериев оценки и сравнительной оценки предложений по тендеру.
+type EvaluationService struct {
+	Repo   repository.EvaluationRepository
+	dbPool *pgxpool.Pool
+	uow    *db.UnitOfWork
+	policy authz.Policy
+	audit  *AuditLogger
+}
+
+// NewEvaluationService создаёт новый экземпляр EvaluationService.
+func NewEvaluationService(repo repository.EvaluationRepository, dbPool *pgxpool.Pool, uow *db.UnitOfWork, policy authz.Policy, audit *AuditLogger) *EvaluationService {
+	return &EvaluationService{Repo: repo, dbPool: dbPool, uow: uow, policy: policy, audit: audit}
+}
+
+// GetCriteria получает критерии оценки предложений по тендеру.
+func (s *EvaluationService) GetCriteria(ctx context.Context, tenderId string) ([]models.EvaluationCriterion, error) {
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetCriteria(ctx, tenderId)
+}
+
+// CreateCriterion добавляет в тендер взвешенный критерий оценки предложений.
+func (s *EvaluationService) CreateCriterion(ctx context.Context, tenderId string, criterionReq models.EvaluationCriterionRequest) (*models.EvaluationCriterion, error) {
+	if criterionReq.Name == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required fields")
+	}
+	switch criterionReq.Kind {
+	case models.PriceCriterion, models.TimeframeCriterion, models.CustomCriterion:
+	default:
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid kind, must be one of: price, timeframe, custom")
+	}
+	if criterionReq.Weight <= 0 {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid weight, must be a positive number")
+	}
+	if err := s.checkCriteriaEdit(ctx, tenderId); err != nil {
+		return nil, err
+	}
+
+	var criterion *models.EvaluationCriterion
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		criterion, err = s.Repo.CreateCriterion(ctx, tenderId, criterionReq)
+		if err != nil {
+			return err
+		}
+		return s.audit.Log(ctx, models.TenderEntity, tenderId, tenderId, models.CriteriaTenderAction, nil, criterion)
+	})
+	return criterion, err
+}
+
+// DeleteCriterion удаляет критерий оценки тендера вместе с оценками предложений по нему.
+func (s *EvaluationService) DeleteCriterion(ctx context.Context, tenderId, criterionId string) error {
+	if err := s.checkCriteriaEdit(ctx, tenderId); err != nil {
+		return err
+	}
+
+	return s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		criterion, err := s.Repo.DeleteCriterion(ctx, tenderId, criterionId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.NewErrorResponse(http.StatusNotFound, "criterion not found")
+			}
+			return err
+		}
+		return s.audit.Log(ctx, models.TenderEntity, tenderId, tenderId, models.CriteriaTenderAction, criterion, nil)
+	})
+}
+
+// ScoreBid сохраняет оценки опубликованного предложения вызывающим ответственным по пользовательским критериям.
+func (s *EvaluationService) ScoreBid(ctx context.Context, bidId string, scores []models.BidScoreRequest) ([]models.BidScore, error) {
+	if len(scores) == 0 {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required fields")
+	}
+	for _, score := range scores {
+		if score.CriterionID == "" || score.Score < 0 || score.Score > models.MaxCriterionScore {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid score, must be a number [0:100] for an existing criterion")
+		}
+	}
+
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.BidScore, authz.BidResource(bidId),
+		"you are not authorized to score this bid")
+	if err != nil {
+		return nil, err
+	}
+	bid, err := utils.GetBidById(ctx, s.dbPool, bidId)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusNotFound, "bid not found")
+	}
+
+	var saved []models.BidScore
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = s.Repo.SaveScores(ctx, bidId, identity.UserID, scores)
+		if err != nil {
+			return err
+		}
+		return s.audit.Log(ctx, models.BidEntity, bidId, bid.TenderId, models.ScoreBidAction, nil, saved)
+	})
+	return saved, err
+}
+
+// GetEvaluation возвращает ответственным за тендер сравнительную матрицу опубликованных предложений,
+// упорядоченную по итоговой взвешенной оценке.
+func (s *EvaluationService) GetEvaluation(ctx context.Context, tenderId string) (*models.TenderEvaluation, error) {
+	if _, err := callerUsername(ctx); err != nil {
+		return nil, err
+	}
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.EvaluationRead, authz.TenderResource(tenderId),
+		"you are not authorized to view the evaluation of this tender")
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := s.Repo.GetCriteria(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	bids, err := s.Repo.GetPublishedBids(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := s.Repo.GetScores(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	return buildEvaluation(tenderId, criteria, bids, scores)
+}
+
+// checkCriteriaEdit проверяет, что вызывающий пользователь может менять критерии оценки тендера.
+func (s *EvaluationService) checkCriteriaEdit(ctx context.Context, tenderId string) error {
+	if _, err := callerUsername(ctx); err != nil {
+		return err
+	}
+	return authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderEdit, authz.TenderResource(tenderId),
+		"you are not authorized to edit this tender")
+}
+
+// buildEvaluation строит сравнительную матрицу предложений.
+// Цена и срок оцениваются относительно лучшего предложения: лучшее получает 100, остальные - долю от 100
+// пропорционально отношению лучшего значения к своему. Пользовательский критерий оценивается средней оценкой
+// ответственных. Итоговая оценка - взвешенное среднее по критериям, критерий без данных даёт 0.
+func buildEvaluation(tenderId string, criteria []models.EvaluationCriterion, bids []models.Bid, scores []models.BidScore) (*models.TenderEvaluation, error) {
+	for _, criterion := range criteria {
+		if criterion.Kind != models.PriceCriterion {
+			continue
+		}
+		if err := checkSameCurrency(bids); err != nil {
+			return nil, err
+		}
+	}
+
+	bestPrice, bestTimeframe := math.Inf(1), math.Inf(1)
+	for _, bid := range bids {
+		if bid.PriceAmount != nil {
+			bestPrice = math.Min(bestPrice, *bid.PriceAmount)
+		}
+		if bid.TimeframeDays != nil {
+			bestTimeframe = math.Min(bestTimeframe, float64(*bid.TimeframeDays))
+		}
+	}
+
+	type scoreSum struct {
+		total float64
+		count int
+	}
+	customScores := make(map[[2]string]*scoreSum)
+	for _, score := range scores {
+		key := [2]string{score.BidID, score.CriterionID}
+		if customScores[key] == nil {
+			customScores[key] = &scoreSum{}
+		}
+		customScores[key].total += score.Score
+		customScores[key].count++
+	}
+
+	var totalWeight float64
+	for _, criterion := range criteria {
+		totalWeight += criterion.Weight
+	}
+
+	evaluations := make([]models.BidEvaluation, 0, len(bids))
+	for _, bid := range bids {
+		evaluation := models.BidEvaluation{BidID: bid.ID, BidName: bid.Name, Criteria: []models.CriterionResult{}}
+		var weighted float64
+		for _, criterion := range criteria {
+			result := models.CriterionResult{CriterionID: criterion.ID}
+			switch criterion.Kind {
+			case models.PriceCriterion:
+				if bid.PriceAmount != nil {
+					result.Score = relativeScore(bestPrice, *bid.PriceAmount)
+				}
+			case models.TimeframeCriterion:
+				if bid.TimeframeDays != nil {
+					result.Score = relativeScore(bestTimeframe, float64(*bid.TimeframeDays))
+				}
+			case models.CustomCriterion:
+				if sum := customScores[[2]string{bid.ID, criterion.ID}]; sum != nil {
+					average := roundScore(sum.total / float64(sum.count))
+					result.Score = &average
+					result.ScoresCount = sum.count
+				}
+			}
+			if result.Score != nil {
+				weighted += criterion.Weight * *result.Score
+			}
+			evaluation.Criteria = append(evaluation.Criteria, result)
+		}
+		if totalWeight > 0 {
+			evaluation.TotalScore = roundScore(weighted / totalWeight)
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+
+	sort.SliceStable(evaluations, func(i, j int) bool {
+		return evaluations[i].TotalScore > evaluations[j].TotalScore
+	})
+	for i := range evaluations {
+		if i > 0 && evaluations[i].TotalScore == evaluations[i-1].TotalScore {
+			evaluations[i].Rank = evaluations[i-1].Rank
+		} else {
+			evaluations[i].Rank = i + 1
+		}
+	}
+
+	return &models.TenderEvaluation{TenderID: tenderId, Criteria: criteria, Bids: evaluations}, nil
+}
+
+// checkSameCurrency проверяет, что цены всех предложений указаны в одной валюте и их можно сравнивать.
+func checkSameCurrency(bids []models.Bid) error {
+	var currency string
+	for _, bid := range bids {
+		if bid.Currency == nil {
+			continue
+		}
+		if currency != "" && *bid.Currency != currency {
+			return models.NewErrorResponse(http.StatusConflict, "bids use different currencies and cannot be compared by price")
+		}
+		currency = *bid.Currency
+	}
+	return nil
+}
+
+// relativeScore оценивает значение, для которого меньшее лучше, относительно лучшего значения best.
+func relativeScore(best, value float64) *float64 {
+	score := float64(models.MaxCriterionScore)
+	if value > 0 {
+		score = roundScore(score * best / value)
+	}
+	return &score
+}
+
+// roundScore округляет оценку до сотых.
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/events"
+)
+
+// EventService - сервис потока событий об изменениях тендеров и предложений.
+type EventService struct {
+	Bus    events.Bus
+	policy authz.Policy
+}
+
+// NewEventService создаёт новый экземпляр EventService.
+func NewEventService(bus events.Bus, policy authz.Policy) *EventService {
+	return &EventService{Bus: bus, policy: policy}
+}
+
+// Subscribe подписывает вызывающего пользователя на события, которые ему разрешено видеть:
+// события тендера - тем, кто может просматривать тендер, события предложения - тем, кто может
+// просматривать предложение. Непустой tenderId ограничивает поток событиями одного тендера.
+// Канал закрывается при отмене контекста.
+func (s *EventService) Subscribe(ctx context.Context, tenderId string) (<-chan events.Event, error) {
+	if _, err := callerIdentity(ctx); err != nil {
+		return nil, err
+	}
+	subject := subjectFromContext(ctx)
+	if tenderId != "" {
+		err := authorize(ctx, s.policy, subject, authz.TenderRead, authz.TenderResource(tenderId),
+			"you are not authorized to view this tender")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subscription := s.Bus.Subscribe()
+	visibleEvents := make(chan events.Event)
+	go func() {
+		defer close(visibleEvents)
+		defer subscription.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-subscription.C:
+				if !ok {
+					return
+				}
+				if tenderId != "" && event.TenderID != tenderId {
+					continue
+				}
+				if !s.canSee(ctx, subject, event) {
+					continue
+				}
+				select {
+				case visibleEvents <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return visibleEvents, nil
+}
+
+// canSee проверяет по политике доступа, может ли пользователь видеть объект события в его текущем состоянии.
+func (s *EventService) canSee(ctx context.Context, subject authz.Subject, event events.Event) bool {
+	action, resource := authz.TenderRead, authz.TenderResource(event.TenderID)
+	if event.IsBidEvent() {
+		action, resource = authz.BidRead, authz.BidResource(event.BidID)
+	}
+	allowed, err := s.policy.Can(ctx, subject, action, resource)
+	return err == nil && allowed
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/events"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+)
+
+// EventPublisher публикует события об изменениях тендеров и предложений в шину событий,
+// ставит их в очередь доставки вебхукам организаций и сохраняет в outbox для ретранслятора.
+// Если Outbox не задан, события в outbox не сохраняются.
+type EventPublisher struct {
+	Bus      events.Bus
+	Webhooks repository.WebhookRepository
+	Outbox   repository.OutboxRepository
+}
+
+// NewEventPublisher создаёт новый экземпляр EventPublisher.
+func NewEventPublisher(bus events.Bus, webhooks repository.WebhookRepository, outbox repository.OutboxRepository) *EventPublisher {
+	return &EventPublisher{Bus: bus, Webhooks: webhooks, Outbox: outbox}
+}
+
+// Publish публикует событие от имени вызывающего пользователя. Метод следует вызывать в той же
+// транзакции, что и само изменение: событие попадает в outbox и очередь доставки вебхукам,
+// только если транзакция зафиксирована.
+func (p *EventPublisher) Publish(ctx context.Context, event events.Event) error {
+	event.Actor = auth.Username(ctx)
+	if p.Outbox != nil {
+		if err := p.Outbox.SaveEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	if err := p.Webhooks.EnqueueDeliveries(ctx, event); err != nil {
+		return err
+	}
+	p.Bus.Publish(ctx, event)
+	return nil
+}
+
+// PublishTender публикует событие об изменении тендера.
+func (p *EventPublisher) PublishTender(ctx context.Context, eventType events.Type, tender *models.Tender) error {
+	return p.Publish(ctx, events.NewTenderEvent(eventType, tender.ID, string(tender.Status), int(tender.Version)))
+}
+
+// PublishBid публикует событие об изменении предложения.
+func (p *EventPublisher) PublishBid(ctx context.Context, eventType events.Type, bid *models.Bid) error {
+	return p.Publish(ctx, events.NewBidEvent(eventType, bid.TenderId, bid.ID, string(bid.Status), bid.Version))
+}
+
+// tenderStatusEvent возвращает тип события о переводе тендера в статус status.
+func tenderStatusEvent(status models.TenderStatus) events.Type {
+	switch status {
+	case models.PublishedTender:
+		return events.TenderPublished
+	case models.ClosedTender:
+		return events.TenderClosed
+	default:
+		return events.TenderEdited
+	}
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+	"github.com/senyabanana/tender-service/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OrganizationService struct {
+	Repo   repository.OrganizationRepository
+	dbPool *pgxpool.Pool
+	uow    *db.UnitOfWork
+	policy authz.Policy
+}
+
+// NewOrganizationService создаёт новый экземпляр OrganizationService.
+func NewOrganizationService(repo repository.OrganizationRepository, dbPool *pgxpool.Pool, uow *db.UnitOfWork, policy authz.Policy) *OrganizationService {
+	return &OrganizationService{Repo: repo, dbPool: dbPool, uow: uow, policy: policy}
+}
+
+// allowedOrganizationTypes - допустимые типы организаций.
+var allowedOrganizationTypes = map[models.OrganizationType]bool{
+	models.IEOrganization:  true,
+	models.LLCOrganization: true,
+	models.JSCOrganization: true,
+}
+
+// CreateOrganization создаёт новую организацию. Создатель становится её первым ответственным.
+func (s *OrganizationService) CreateOrganization(ctx context.Context, organizationReq models.OrganizationRequest) (*models.OrganizationInfo, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if organizationReq.Name == "" || organizationReq.Type == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required fields")
+	}
+	if len(organizationReq.Name) > 100 {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "name must not exceed 100 characters")
+	}
+	if len(organizationReq.Description) > 500 {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "description must not exceed 500 characters")
+	}
+	if !allowedOrganizationTypes[organizationReq.Type] {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid organization type")
+	}
+
+	inOrganization, err := utils.CheckUserInAnyOrganization(ctx, s.dbPool, identity.UserID)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+	}
+	if inOrganization {
+		return nil, models.NewErrorResponse(http.StatusConflict, "user is already responsible for another organization")
+	}
+
+	var organization *models.OrganizationInfo
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		organization, err = s.Repo.CreateOrganization(ctx, organizationReq)
+		if err != nil {
+			return err
+		}
+		return s.Repo.AddResponsible(ctx, organization.ID, identity.UserID)
+	})
+	return organization, err
+}
+
+// GetOrganization получает организацию по id.
+func (s *OrganizationService) GetOrganization(ctx context.Context, organizationId string) (*models.OrganizationInfo, error) {
+	if _, err := uuid.Parse(organizationId); err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid organizationId")
+	}
+
+	organization, err := s.Repo.GetOrganization(ctx, organizationId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.NewErrorResponse(http.StatusNotFound, "organization not found")
+		}
+		return nil, err
+	}
+	return organization, nil
+}
+
+// GetOrganizations получает список организаций.
+func (s *OrganizationService) GetOrganizations(ctx context.Context, limitStr, offsetStr string) ([]models.OrganizationInfo, error) {
+	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, err.Error())
+	}
+	return s.Repo.GetOrganizations(ctx, limit, offset)
+}
+
+// UpdateOrganization меняет данные организации. Доступно только ответственным за организацию.
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, organizationId string, updateFields map[string]interface{}) (*models.OrganizationInfo, error) {
+	if err := s.checkResponsible(ctx, organizationId); err != nil {
+		return nil, err
+	}
+
+	validFields := make(map[string]interface{})
+	if value, ok := updateFields["name"]; ok {
+		name, ok := value.(string)
+		if !ok || name == "" || len(name) > 100 {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid name, must be a non-empty string up to 100 characters")
+		}
+		validFields["name"] = name
+	}
+	if value, ok := updateFields["description"]; ok {
+		description, ok := value.(string)
+		if !ok || len(description) > 500 {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid description, must be a string up to 500 characters")
+		}
+		validFields["description"] = description
+	}
+	if value, ok := updateFields["type"]; ok {
+		organizationType, ok := value.(string)
+		if !ok || !allowedOrganizationTypes[models.OrganizationType(organizationType)] {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid organization type: %v", value))
+		}
+		validFields["type"] = organizationType
+	}
+	if len(validFields) == 0 {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "No valid fields to update")
+	}
+
+	return s.Repo.UpdateOrganization(ctx, organizationId, validFields)
+}
+
+// DeleteOrganization удаляет организацию. Доступно только ответственным за организацию.
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, organizationId string) error {
+	if err := s.checkResponsible(ctx, organizationId); err != nil {
+		return err
+	}
+	return s.Repo.DeleteOrganization(ctx, organizationId)
+}
+
+// GetResponsibles получает список ответственных за организацию.
+func (s *OrganizationService) GetResponsibles(ctx context.Context, organizationId string) ([]models.Employee, error) {
+	if _, err := s.GetOrganization(ctx, organizationId); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetResponsibles(ctx, organizationId)
+}
+
+// AddResponsible назначает сотрудника ответственным за организацию.
+// Сотрудник может быть ответственным только в одной организации.
+func (s *OrganizationService) AddResponsible(ctx context.Context, organizationId, userId string) ([]models.Employee, error) {
+	if err := s.checkResponsible(ctx, organizationId); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userId); err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid userId")
+	}
+
+	exists, err := utils.CheckUserExistsById(ctx, s.dbPool, userId)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+	}
+	if !exists {
+		return nil, models.NewErrorResponse(http.StatusNotFound, "user not found")
+	}
+
+	inOrganization, err := utils.CheckUserInAnyOrganization(ctx, s.dbPool, userId)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+	}
+	if inOrganization {
+		return nil, models.NewErrorResponse(http.StatusConflict, "user is already responsible for an organization")
+	}
+
+	var responsibles []models.Employee
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.Repo.AddResponsible(ctx, organizationId, userId); err != nil {
+			return err
+		}
+		var err error
+		responsibles, err = s.Repo.GetResponsibles(ctx, organizationId)
+		return err
+	})
+	return responsibles, err
+}
+
+// RemoveResponsible снимает с сотрудника ответственность за организацию.
+// Последнего ответственного снять нельзя.
+func (s *OrganizationService) RemoveResponsible(ctx context.Context, organizationId, userId string) ([]models.Employee, error) {
+	if err := s.checkResponsible(ctx, organizationId); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userId); err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid userId")
+	}
+
+	var responsibles []models.Employee
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.Repo.RemoveResponsible(ctx, organizationId, userId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.NewErrorResponse(http.StatusNotFound, "user is not responsible for this organization")
+			}
+			return err
+		}
+
+		var err error
+		responsibles, err = s.Repo.GetResponsibles(ctx, organizationId)
+		if err != nil {
+			return err
+		}
+		if len(responsibles) == 0 {
+			return models.NewErrorResponse(http.StatusConflict, "cannot remove the last responsible of the organization")
+		}
+		return nil
+	})
+	return responsibles, err
+}
+
+// checkResponsible проверяет, что организация существует и вызывающий пользователь является её ответственным.
+func (s *OrganizationService) checkResponsible(ctx context.Context, organizationId string) error {
+	if _, err := callerUsername(ctx); err != nil {
+		return err
+	}
+	if _, err := s.GetOrganization(ctx, organizationId); err != nil {
+		return err
+	}
+	return authorize(ctx, s.policy, subjectFromContext(ctx), authz.OrganizationManage, authz.OrganizationResource(organizationId),
+		"you are not responsible for this organization")
+}
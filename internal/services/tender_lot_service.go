@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/events"
+	"github.com/senyabanana/tender-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetTenderLots получает список лотов тендера.
+func (s *TenderService) GetTenderLots(ctx context.Context, tenderId string) ([]models.TenderLot, error) {
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetTenderLots(ctx, tenderId)
+}
+
+// GetTenderLot получает лот тендера.
+func (s *TenderService) GetTenderLot(ctx context.Context, tenderId, lotId string) (*models.TenderLot, error) {
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return nil, err
+	}
+	lot, err := s.Repo.GetTenderLot(ctx, tenderId, lotId)
+	if err != nil {
+		return nil, lotNotFound(err)
+	}
+	return lot, nil
+}
+
+// CreateTenderLot добавляет лот в тендер. Изменение лотов создаёт новую версию тендера,
+// поэтому ненулевая expectedVersion должна совпадать с текущей версией тендера.
+func (s *TenderService) CreateTenderLot(ctx context.Context, tenderId string, expectedVersion int, lotReq models.TenderLotRequest) (*models.TenderLot, *models.Tender, error) {
+	if err := s.checkTenderEdit(ctx, tenderId); err != nil {
+		return nil, nil, err
+	}
+
+	var lot *models.TenderLot
+	var updatedTender *models.Tender
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		lot, updatedTender, err = s.Repo.CreateTenderLot(ctx, tenderId, expectedVersion, lotReq)
+		if err != nil {
+			return err
+		}
+		if err = s.logTenderChange(ctx, models.LotsTenderAction, updatedTender); err != nil {
+			return err
+		}
+		return s.events.PublishTender(ctx, events.TenderEdited, updatedTender)
+	})
+	return lot, updatedTender, err
+}
+
+// EditTenderLot меняет поля лота тендера.
+// Ненулевая expectedVersion должна совпадать с текущей версией тендера.
+func (s *TenderService) EditTenderLot(ctx context.Context, tenderId, lotId string, expectedVersion int, updateFields map[string]interface{}) (*models.TenderLot, *models.Tender, error) {
+	if err := s.checkTenderEdit(ctx, tenderId); err != nil {
+		return nil, nil, err
+	}
+
+	var lot *models.TenderLot
+	var updatedTender *models.Tender
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		lot, updatedTender, err = s.Repo.EditTenderLot(ctx, tenderId, lotId, expectedVersion, updateFields)
+		if err != nil {
+			return lotNotFound(err)
+		}
+		if err = s.logTenderChange(ctx, models.LotsTenderAction, updatedTender); err != nil {
+			return err
+		}
+		return s.events.PublishTender(ctx, events.TenderEdited, updatedTender)
+	})
+	return lot, updatedTender, err
+}
+
+// DeleteTenderLot удаляет лот тендера.
+// Ненулевая expectedVersion должна совпадать с текущей версией тендера.
+func (s *TenderService) DeleteTenderLot(ctx context.Context, tenderId, lotId string, expectedVersion int) (*models.Tender, error) {
+	if err := s.checkTenderEdit(ctx, tenderId); err != nil {
+		return nil, err
+	}
+
+	var updatedTender *models.Tender
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTender, err = s.Repo.DeleteTenderLot(ctx, tenderId, lotId, expectedVersion)
+		if err != nil {
+			return lotNotFound(err)
+		}
+		if err = s.logTenderChange(ctx, models.LotsTenderAction, updatedTender); err != nil {
+			return err
+		}
+		return s.events.PublishTender(ctx, events.TenderEdited, updatedTender)
+	})
+	return updatedTender, err
+}
+
+// checkTenderEdit проверяет, что вызывающий пользователь может менять лоты и вложения тендера.
+func (s *TenderService) checkTenderEdit(ctx context.Context, tenderId string) error {
+	if _, err := callerUsername(ctx); err != nil {
+		return err
+	}
+	return authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderEdit, authz.TenderResource(tenderId),
+		"you are not authorized to edit this tender")
+}
+
+// lotNotFound заменяет отсутствие строки ошибкой 404 "lot not found".
+func lotNotFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.NewErrorResponse(http.StatusNotFound, "lot not found")
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TenderQuestionService - сервис публичных вопросов и ответов по тендерам.
+type TenderQuestionService struct {
+	Repo   repository.TenderQuestionRepository
+	uow    *db.UnitOfWork
+	policy authz.Policy
+	audit  *AuditLogger
+}
+
+// NewTenderQuestionService создаёт новый экземпляр TenderQuestionService.
+func NewTenderQuestionService(repo repository.TenderQuestionRepository, uow *db.UnitOfWork, policy authz.Policy, audit *AuditLogger) *TenderQuestionService {
+	return &TenderQuestionService{Repo: repo, uow: uow, policy: policy, audit: audit}
+}
+
+// GetQuestions получает страницу списка вопросов по тендеру. Вопросы с ответом видны всем, кто видит тендер,
+// вопросы без ответа - автору вопроса и ответственным за организацию тендера.
+func (s *TenderQuestionService) GetQuestions(ctx context.Context, tenderId string, page models.Page) (models.Paged[models.TenderQuestion], error) {
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return models.Paged[models.TenderQuestion]{}, err
+	}
+	identity, _ := auth.FromContext(ctx)
+	return s.Repo.GetQuestions(ctx, tenderId, identity.UserID, page)
+}
+
+// AskQuestion задаёт вопрос по опубликованному тендеру. Вопрос может задать любой пользователь.
+// Вопрос не записывается в журнал аудита, чтобы организация тендера не узнала его автора.
+func (s *TenderQuestionService) AskQuestion(ctx context.Context, tenderId string, questionReq models.TenderQuestionRequest) (*models.TenderQuestion, error) {
+	if err := models.ValidateTenderQuestion(questionReq); err != nil {
+		return nil, err
+	}
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.QuestionAsk, authz.TenderResource(tenderId),
+		"questions can only be asked on published tenders")
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.CreateQuestion(ctx, tenderId, identity.UserID, questionReq)
+}
+
+// AnswerQuestion отвечает на вопрос по тендеру. Отвечать могут только ответственные за организацию тендера,
+// ответ даётся один раз и становится виден всем, кто видит тендер.
+func (s *TenderQuestionService) AnswerQuestion(ctx context.Context, tenderId, questionId string, answerReq models.TenderAnswerRequest) (*models.TenderQuestion, error) {
+	if err := models.ValidateTenderAnswer(answerReq); err != nil {
+		return nil, err
+	}
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.QuestionAnswer, authz.TenderResource(tenderId),
+		"only responsibles for the tender organization can answer questions")
+	if err != nil {
+		return nil, err
+	}
+
+	var question *models.TenderQuestion
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		question, err = s.Repo.AnswerQuestion(ctx, tenderId, questionId, identity.UserID, answerReq)
+		if err != nil {
+			return questionNotFound(err)
+		}
+		return s.audit.Log(ctx, models.TenderEntity, tenderId, tenderId, models.AnswerTenderAction, nil, question)
+	})
+	return question, err
+}
+
+// questionNotFound заменяет отсутствие строки ошибкой 404 "question not found".
+func questionNotFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.NewErrorResponse(http.StatusNotFound, "question not found")
+	}
+	return err
+}
@@ -2,29 +2,51 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/senyabanana/tender-service/internal/auth"
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/events"
 	"github.com/senyabanana/tender-service/internal/models"
 	"github.com/senyabanana/tender-service/internal/repository"
 	"github.com/senyabanana/tender-service/internal/utils"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type TenderService struct {
-	Repo   repository.TenderRepository
-	dbPool *pgxpool.Pool
+	Repo        repository.TenderRepository
+	Attachments *AttachmentStorage
+	dbPool      *pgxpool.Pool
+	uow         *db.UnitOfWork
+	policy      authz.Policy
+	audit       *AuditLogger
+	events      *EventPublisher
 }
 
 // NewTenderService создаёт новый экземпляр TenderService.
-func NewTenderService(repo repository.TenderRepository, dbPool *pgxpool.Pool) *TenderService {
-	return &TenderService{Repo: repo, dbPool: dbPool}
+func NewTenderService(repo repository.TenderRepository, dbPool *pgxpool.Pool, uow *db.UnitOfWork, policy authz.Policy, audit *AuditLogger, publisher *EventPublisher, attachments *AttachmentStorage) *TenderService {
+	return &TenderService{Repo: repo, Attachments: attachments, dbPool: dbPool, uow: uow, policy: policy, audit: audit, events: publisher}
 }
 
-// FetchTenders получает список тендеров.
-func (s *TenderService) FetchTenders(ctx context.Context, limit, offset int, serviceTypes []string) ([]models.Tender, error) {
+// FetchTenders получает страницу списка тендеров с учётом фильтров и сортировки. Анонимный пользователь видит
+// только опубликованные тендеры, ответственный за организацию - также тендеры своей организации в любом статусе.
+func (s *TenderService) FetchTenders(ctx context.Context, page models.Page, filter models.TenderFilter) (models.Paged[models.Tender], error) {
+	if err := validateServiceTypes(filter.ServiceTypes); err != nil {
+		return models.Paged[models.Tender]{}, err
+	}
+	identity, _ := auth.FromContext(ctx)
+	return s.Repo.GetTenders(ctx, page, filter, identity.UserID)
+}
+
+// validateServiceTypes проверяет, что все типы услуг фильтра поддерживаются.
+func validateServiceTypes(serviceTypes []string) error {
 	allowedServiceTypes := map[models.TenderServiceType]bool{
 		models.Construction: true,
 		models.Delivery:     true,
@@ -33,14 +55,31 @@ func (s *TenderService) FetchTenders(ctx context.Context, limit, offset int, ser
 	for _, serviceType := range serviceTypes {
 		tenderServiceType := models.TenderServiceType(serviceType)
 		if !allowedServiceTypes[tenderServiceType] {
-			return nil, models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("unsupported service type: %s", serviceType))
+			return models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("unsupported service type: %s", serviceType))
 		}
 	}
-	return s.Repo.GetTenders(ctx, limit, offset, serviceTypes)
+	return nil
+}
+
+// SearchTenders ищет тендеры по тексту названия и описания и фильтрам с теми же правилами видимости,
+// что и FetchTenders, и возвращает результаты вместе с фасетами по типу услуги и статусу.
+func (s *TenderService) SearchTenders(ctx context.Context, search models.TenderSearch, limit, offset int) (*models.TenderSearchResult, error) {
+	if err := models.ValidateTenderSearch(search); err != nil {
+		return nil, err
+	}
+	identity, _ := auth.FromContext(ctx)
+	return s.Repo.SearchTenders(ctx, search, identity.UserID, limit, offset)
 }
 
-// CreateTender создает новый тендер.
+// CreateTender создает новый тендер от имени вызывающего пользователя. Поле creatorUsername из тела
+// запроса заменяется пользователем, определённым по токену или, в режиме совместимости, по параметру username.
 func (s *TenderService) CreateTender(ctx context.Context, tenderReq models.TenderRequest) (*models.Tender, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenderReq.CreatorUsername = identity.Username
+
 	if tenderReq.Name == "" || tenderReq.Description == "" || tenderReq.OrganizationID == "" || tenderReq.CreatorUsername == "" {
 		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required fields")
 	}
@@ -53,12 +92,12 @@ func (s *TenderService) CreateTender(ctx context.Context, tenderReq models.Tende
 		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
 	}
 
-	isResponsible, err := utils.CheckUserResponsibleForOrganization(ctx, s.dbPool, tenderReq.CreatorUsername, tenderReq.OrganizationID)
+	subject := subjectFromContext(ctx)
+	subject.Username = tenderReq.CreatorUsername
+	err = authorize(ctx, s.policy, subject, authz.TenderCreate, authz.OrganizationResource(tenderReq.OrganizationID),
+		"you are not authorized to create tenders for this organization")
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
-	}
-	if !isResponsible {
-		return nil, models.NewErrorResponse(http.StatusForbidden, "you are not authorized to create tenders for this organization")
+		return nil, err
 	}
 
 	allowedServiceTypes := map[models.TenderServiceType]bool{
@@ -70,154 +109,334 @@ func (s *TenderService) CreateTender(ctx context.Context, tenderReq models.Tende
 		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid service type")
 	}
 
-	return s.Repo.CreateTender(ctx, tenderReq)
-}
+	if tenderReq.SubmissionDeadline != nil && !tenderReq.SubmissionDeadline.After(time.Now()) {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "submissionDeadline must be in the future")
+	}
+	if err = models.ValidateTenderDeadlines(tenderReq.SubmissionDeadline, tenderReq.DecisionDeadline); err != nil {
+		return nil, err
+	}
+	if tenderReq.BidsOpeningAt != nil && !tenderReq.BidsOpeningAt.After(time.Now()) {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "bidsOpeningAt must be in the future")
+	}
+	tenderReq.SubmissionDeadline = utcTime(tenderReq.SubmissionDeadline)
+	tenderReq.DecisionDeadline = utcTime(tenderReq.DecisionDeadline)
+	tenderReq.BidsOpeningAt = utcTime(tenderReq.BidsOpeningAt)
 
-// GetUserTender получает список тендеров для пользователя.
-func (s *TenderService) GetUserTender(ctx context.Context, limitStr, offsetStr, username string) ([]models.Tender, error) {
-	if username != "" {
-		exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	var tender *models.Tender
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		tender, err = s.Repo.CreateTender(ctx, tenderReq)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
-		}
-		if !exists {
-			return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+			return err
 		}
+		return s.audit.Log(ctx, models.TenderEntity, tender.ID, tender.ID, models.CreateTenderAction, nil, tender)
+	})
+	return tender, err
+}
+
+// GetUserTender получает страницу списка тендеров, созданных пользователем, с учётом фильтров и сортировки.
+func (s *TenderService) GetUserTender(ctx context.Context, page models.Page, filter models.TenderFilter) (models.Paged[models.Tender], error) {
+	if err := validateServiceTypes(filter.ServiceTypes); err != nil {
+		return models.Paged[models.Tender]{}, err
 	}
 
-	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+	username, err := callerUsername(ctx)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, err.Error())
+		return models.Paged[models.Tender]{}, err
 	}
-	return s.Repo.GetUserTender(ctx, limit, offset, username)
-}
 
-// GetTenderStatus получает статус тендера.
-func (s *TenderService) GetTenderStatus(ctx context.Context, tenderId, username string) (models.TenderStatus, error) {
-	if username != "" {
-		exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
-		if err != nil {
-			return "", models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
-		}
-		if !exists {
-			return "", models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
-		}
-		isAuthorized, err := utils.CheckUserAuthorized(ctx, s.dbPool, username, tenderId)
+	exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	if err != nil {
+		return models.Paged[models.Tender]{}, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+	}
+	if !exists {
+		return models.Paged[models.Tender]{}, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+	}
+
+	tenders, err := s.Repo.GetUserTender(ctx, page, filter, username)
+	if err != nil {
+		return models.Paged[models.Tender]{}, err
+	}
+
+	subject := subjectFromContext(ctx)
+	for _, t := range tenders.Items {
+		err = authorize(ctx, s.policy, subject, authz.TenderCreate, authz.OrganizationResource(t.OrganizationID),
+			"you do not have permission to view tenders for this organization")
 		if err != nil {
-			return "", models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
-		}
-		if !isAuthorized {
-			return "", models.NewErrorResponse(http.StatusForbidden, "you are not authorized to edit this tender")
+			return models.Paged[models.Tender]{}, err
 		}
 	}
-	tenderExists, err := utils.CheckTenderExists(ctx, s.dbPool, tenderId)
-	if !tenderExists && err != nil {
-		return "", models.NewErrorResponse(http.StatusNotFound, "tender not found")
+	return tenders, nil
+}
+
+// GetTenderStatus получает статус тендера.
+func (s *TenderService) GetTenderStatus(ctx context.Context, tenderId string) (models.TenderStatus, error) {
+	err := authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender")
+	if err != nil {
+		return "", err
 	}
-	return s.Repo.GetTenderStatus(ctx, tenderId, username)
+	return s.Repo.GetTenderStatus(ctx, tenderId)
 }
 
 // UpdateTenderStatus меняет статус тендера.
-func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderId, status, username string) (*models.Tender, error) {
-	if status == "" || username == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameters: status or username")
+// Ненулевая expectedVersion должна совпадать с текущей версией тендера.
+func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderId, status string, expectedVersion int) (*models.Tender, error) {
+	if status == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: status")
+	}
+	username, err := callerUsername(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if username != "" {
-		exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+	}
+	if !exists {
+		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+	}
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderPublish, authz.TenderResource(tenderId),
+		"you are not authorized to edit this tender")
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedTender *models.Tender
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTender, err = s.Repo.UpdateTenderStatus(ctx, tenderId, status, expectedVersion)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+			return err
 		}
-		if !exists {
-			return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+		if err = s.logTenderChange(ctx, models.StatusTenderAction, updatedTender); err != nil {
+			return err
 		}
-		isAuthorized, err := utils.CheckUserAuthorized(ctx, s.dbPool, username, tenderId)
+		return s.events.PublishTender(ctx, tenderStatusEvent(updatedTender.Status), updatedTender)
+	})
+	return updatedTender, err
+}
+
+// EditTender меняет описание тендера.
+// Ненулевая expectedVersion должна совпадать с текущей версией тендера.
+func (s *TenderService) EditTender(ctx context.Context, tenderId string, expectedVersion int, updateFields map[string]interface{}) (*models.Tender, error) {
+	if tenderId == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: tenderId")
+	}
+	username, err := callerUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+	}
+	if !exists {
+		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+	}
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderEdit, authz.TenderResource(tenderId),
+		"you are not authorized to edit this tender")
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedTender *models.Tender
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTender, err = s.Repo.EditTender(ctx, tenderId, expectedVersion, updateFields)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
+			return err
 		}
-		if !isAuthorized {
-			return nil, models.NewErrorResponse(http.StatusForbidden, "you are not authorized to edit this tender")
+		if err = s.logTenderChange(ctx, models.EditTenderAction, updatedTender); err != nil {
+			return err
 		}
-	}
+		return s.events.PublishTender(ctx, events.TenderEdited, updatedTender)
+	})
+	return updatedTender, err
+}
 
-	currentTender, err := utils.GetTenderById(ctx, s.dbPool, tenderId)
+// RollbackTender откатывает версию тендера.
+// Ненулевая expectedVersion должна совпадать с текущей версией тендера.
+func (s *TenderService) RollbackTender(ctx context.Context, tenderId, versionStr string, expectedVersion int) (*models.Tender, error) {
+	if tenderId == "" || versionStr == "" {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: tenderId or version")
+	}
+	username, err := callerUsername(ctx)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
+		return nil, err
 	}
 
-	allowedStatusTransition := map[models.TenderStatus][]models.TenderStatus{
-		models.CreatedTender:   {models.PublishedTender, models.ClosedTender},
-		models.PublishedTender: {models.ClosedTender},
-		models.ClosedTender:    {},
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid version number")
 	}
 
-	validTransition := allowedStatusTransition[currentTender.Status]
-	if !utils.ContainsTender(validTransition, models.TenderStatus(status)) {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid tender status")
+	exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server error")
 	}
-	return s.Repo.UpdateTenderStatus(ctx, tenderId, status)
-}
-
-// EditTender меняет описание тендера.
-func (s *TenderService) EditTender(ctx context.Context, tenderId, username string, updateFields map[string]interface{}) (*models.Tender, error) {
-	if username == "" || tenderId == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: tenderId or username")
+	if !exists {
+		return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
 	}
-
-	tenderExists, err := utils.CheckTenderExists(ctx, s.dbPool, tenderId)
-	if !tenderExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
+	err = authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderRollback, authz.TenderResource(tenderId),
+		"you are not authorized to edit this tender")
+	if err != nil {
+		return nil, err
 	}
 
-	if username != "" {
-		exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
-		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server 1error")
-		}
-		if !exists {
-			return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
-		}
-		isAuthorized, err := utils.CheckUserAuthorized(ctx, s.dbPool, username, tenderId)
+	var updatedTender *models.Tender
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTender, err = s.Repo.RollbackTender(ctx, tenderId, version, expectedVersion)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server 2error")
+			return err
 		}
-		if !isAuthorized {
-			return nil, models.NewErrorResponse(http.StatusForbidden, "you are not authorized to edit this tender")
+		if err = s.logTenderChange(ctx, models.RollbackTenderAction, updatedTender); err != nil {
+			return err
 		}
+		return s.events.PublishTender(ctx, events.TenderEdited, updatedTender)
+	})
+	return updatedTender, err
+}
+
+// GetTenderVersions получает список всех версий тендера.
+func (s *TenderService) GetTenderVersions(ctx context.Context, tenderId string) ([]models.Tender, error) {
+	if err := s.checkTenderHistoryAccess(ctx, tenderId); err != nil {
+		return nil, err
 	}
-	return s.Repo.EditTender(ctx, tenderId, updateFields)
+	return s.Repo.GetTenderVersions(ctx, tenderId)
 }
 
-// RollbackTender откатывает версию тендера
-func (s *TenderService) RollbackTender(ctx context.Context, tenderId, username, versionStr string) (*models.Tender, error) {
-	if username == "" || tenderId == "" || versionStr == "" {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: tenderId or username or version")
+// GetTenderVersion получает снимок тендера указанной версии.
+func (s *TenderService) GetTenderVersion(ctx context.Context, tenderId, versionStr string) (*models.Tender, error) {
+	version, err := parseVersion("version", versionStr)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkTenderHistoryAccess(ctx, tenderId); err != nil {
+		return nil, err
 	}
+	return s.getTenderVersion(ctx, tenderId, version)
+}
 
-	version, err := strconv.Atoi(versionStr)
+// DiffTenderVersions сравнивает две версии тендера по полям.
+func (s *TenderService) DiffTenderVersions(ctx context.Context, tenderId, fromStr, toStr string) (*models.VersionDiff, error) {
+	from, err := parseVersion("from", fromStr)
 	if err != nil {
-		return nil, models.NewErrorResponse(http.StatusBadRequest, "invalid version number")
+		return nil, err
+	}
+	to, err := parseVersion("to", toStr)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkTenderHistoryAccess(ctx, tenderId); err != nil {
+		return nil, err
 	}
 
-	tenderExists, err := utils.CheckTenderExists(ctx, s.dbPool, tenderId)
-	if !tenderExists && err != nil {
-		return nil, models.NewErrorResponse(http.StatusNotFound, "tender not found")
+	fromTender, err := s.getTenderVersion(ctx, tenderId, from)
+	if err != nil {
+		return nil, err
 	}
+	toTender, err := s.getTenderVersion(ctx, tenderId, to)
+	if err != nil {
+		return nil, err
+	}
+	return &models.VersionDiff{From: from, To: to, Changes: diffTenders(fromTender, toTender)}, nil
+}
+
+// getTenderVersion получает снимок тендера и возвращает 404, если такой версии нет.
+func (s *TenderService) getTenderVersion(ctx context.Context, tenderId string, version int) (*models.Tender, error) {
+	tender, err := s.Repo.GetTenderVersion(ctx, tenderId, version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.NewErrorResponse(http.StatusNotFound, fmt.Sprintf("version %d not found", version))
+		}
+		return nil, err
+	}
+	return tender, nil
+}
+
+// checkTenderHistoryAccess проверяет, что тендер существует и вызывающий пользователь может просматривать его историю.
+func (s *TenderService) checkTenderHistoryAccess(ctx context.Context, tenderId string) error {
+	if _, err := callerUsername(ctx); err != nil {
+		return err
+	}
+	return authorize(ctx, s.policy, subjectFromContext(ctx), authz.TenderHistoryRead, authz.TenderResource(tenderId),
+		"you are not authorized to view this tender history")
+}
 
-	if username != "" {
-		exists, err := utils.CheckUserExists(ctx, s.dbPool, username)
+// CloseExpiredTenders закрывает не более limit опубликованных тендеров с истёкшим сроком подачи предложений.
+// Закрытие записывается в историю и журнал аудита от имени системного пользователя.
+func (s *TenderService) CloseExpiredTenders(ctx context.Context, limit int) ([]models.Tender, error) {
+	ctx = auth.WithIdentity(ctx, auth.Identity{Username: SystemActor})
+
+	var closedTenders []models.Tender
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		closedTenders, err = s.Repo.CloseExpiredTenders(ctx, time.Now().UTC(), limit)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server 1error")
+			return err
 		}
-		if !exists {
-			return nil, models.NewErrorResponse(http.StatusUnauthorized, "user does not exist")
+		for i := range closedTenders {
+			if err = s.logTenderChange(ctx, models.StatusTenderAction, &closedTenders[i]); err != nil {
+				return err
+			}
+			if err = s.events.PublishTender(ctx, events.TenderClosed, &closedTenders[i]); err != nil {
+				return err
+			}
 		}
-		isAuthorized, err := utils.CheckUserAuthorized(ctx, s.dbPool, username, tenderId)
+		return nil
+	})
+	return closedTenders, err
+}
+
+// OpenSealedBids вскрывает предложения не более limit закрытых тендеров, которые закрыты или время вскрытия
+// которых наступило. Вскрытие записывается в историю и журнал аудита от имени системного пользователя.
+func (s *TenderService) OpenSealedBids(ctx context.Context, limit int) ([]models.Tender, error) {
+	ctx = auth.WithIdentity(ctx, auth.Identity{Username: SystemActor})
+
+	var openedTenders []models.Tender
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		openedTenders, err = s.Repo.OpenSealedBids(ctx, time.Now().UTC(), limit)
 		if err != nil {
-			return nil, models.NewErrorResponse(http.StatusInternalServerError, "internal server 2error")
+			return err
 		}
-		if !isAuthorized {
-			return nil, models.NewErrorResponse(http.StatusForbidden, "you are not authorized to edit this tender")
+		for i := range openedTenders {
+			if err = s.logTenderChange(ctx, models.OpenBidsTenderAction, &openedTenders[i]); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	return openedTenders, err
+}
+
+// logTenderChange записывает в журнал аудита изменение тендера, создавшее новую версию.
+func (s *TenderService) logTenderChange(ctx context.Context, action models.AuditAction, updatedTender *models.Tender) error {
+	return logTenderVersionChange(ctx, s.Repo, s.audit, action, updatedTender)
+}
+
+// logTenderVersionChange записывает в журнал аудита изменение тендера, создавшее новую версию.
+// Состояния до и после изменения берутся из снимков версий, чтобы в журнал попадали и лоты тендера.
+func logTenderVersionChange(ctx context.Context, repo repository.TenderRepository, audit *AuditLogger, action models.AuditAction, updatedTender *models.Tender) error {
+	previousTender, err := repo.GetTenderVersion(ctx, updatedTender.ID, int(updatedTender.Version)-1)
+	if err != nil {
+		return err
+	}
+	currentTender, err := repo.GetTenderVersion(ctx, updatedTender.ID, int(updatedTender.Version))
+	if err != nil {
+		return err
+	}
+	return audit.Log(ctx, models.TenderEntity, updatedTender.ID, updatedTender.ID, action, previousTender, currentTender)
+}
+
+// utcTime приводит необязательное время к UTC.
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
 	}
-	return s.Repo.RollbackTender(ctx, tenderId, version)
+	utc := t.UTC()
+	return &utc
 }
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/senyabanana/tender-service/internal/models"
+)
+
+// parseVersion разбирает номер версии из параметра запроса.
+func parseVersion(name, value string) (int, error) {
+	if value == "" {
+		return 0, models.NewErrorResponse(http.StatusBadRequest, "missing required query parameter: "+name)
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		return 0, models.NewErrorResponse(http.StatusBadRequest, "invalid "+name+" parameter, must be a positive integer")
+	}
+	return version, nil
+}
+
+// appendChange добавляет изменение поля в список, если значения в версиях различаются.
+func appendChange(changes []models.FieldChange, field string, from, to interface{}) []models.FieldChange {
+	if reflect.DeepEqual(from, to) {
+		return changes
+	}
+	return append(changes, models.FieldChange{Field: field, From: from, To: to})
+}
+
+// diffTenders возвращает список полей, изменившихся между двумя версиями тендера.
+func diffTenders(from, to *models.Tender) []models.FieldChange {
+	changes := []models.FieldChange{}
+	changes = appendChange(changes, "name", from.Name, to.Name)
+	changes = appendChange(changes, "description", from.Description, to.Description)
+	changes = appendChange(changes, "serviceType", from.ServiceType, to.ServiceType)
+	changes = appendChange(changes, "status", from.Status, to.Status)
+	changes = appendChange(changes, "submissionDeadline", from.SubmissionDeadline, to.SubmissionDeadline)
+	changes = appendChange(changes, "decisionDeadline", from.DecisionDeadline, to.DecisionDeadline)
+	changes = appendChange(changes, "sealed", from.Sealed, to.Sealed)
+	changes = appendChange(changes, "bidsOpeningAt", from.BidsOpeningAt, to.BidsOpeningAt)
+	changes = appendChange(changes, "bidsOpenedAt", from.BidsOpenedAt, to.BidsOpenedAt)
+	changes = appendChange(changes, "lots", from.Lots, to.Lots)
+	changes = appendChange(changes, "attachments", from.Attachments, to.Attachments)
+	return changes
+}
+
+// diffBids возвращает список полей, изменившихся между двумя версиями предложения.
+func diffBids(from, to *models.Bid) []models.FieldChange {
+	changes := []models.FieldChange{}
+	changes = appendChange(changes, "name", from.Name, to.Name)
+	changes = appendChange(changes, "description", from.Description, to.Description)
+	changes = appendChange(changes, "status", from.Status, to.Status)
+	changes = appendChange(changes, "priceAmount", from.PriceAmount, to.PriceAmount)
+	changes = appendChange(changes, "currency", from.Currency, to.Currency)
+	changes = appendChange(changes, "timeframeDays", from.TimeframeDays, to.TimeframeDays)
+	changes = appendChange(changes, "warrantyTerms", from.WarrantyTerms, to.WarrantyTerms)
+	changes = appendChange(changes, "lotPrices", from.LotPrices, to.LotPrices)
+	changes = appendChange(changes, "attachments", from.Attachments, to.Attachments)
+	return changes
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/senyabanana/tender-service/internal/authz"
+	"github.com/senyabanana/tender-service/internal/events"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+	"github.com/senyabanana/tender-service/internal/utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	minWebhookSecretLength = 16  // Минимальная длина секрета, заданного организацией
+	maxWebhookSecretLength = 128 // Максимальная длина секрета, заданного организацией
+)
+
+// WebhookService - сервис вебхуков организаций.
+type WebhookService struct {
+	Repo   repository.WebhookRepository
+	policy authz.Policy
+}
+
+// NewWebhookService создаёт новый экземпляр WebhookService.
+func NewWebhookService(repo repository.WebhookRepository, policy authz.Policy) *WebhookService {
+	return &WebhookService{Repo: repo, policy: policy}
+}
+
+// GetWebhooks получает вебхуки организации. Доступно только её ответственным.
+func (s *WebhookService) GetWebhooks(ctx context.Context, organizationId string) ([]models.Webhook, error) {
+	if err := s.checkResponsible(ctx, organizationId); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetWebhooks(ctx, organizationId)
+}
+
+// CreateWebhook регистрирует вебхук организации. Если секрет не указан, он генерируется;
+// секрет возвращается только в ответе на этот запрос.
+func (s *WebhookService) CreateWebhook(ctx context.Context, organizationId string, webhookReq models.WebhookRequest) (*models.Webhook, error) {
+	if err := models.ValidateWebhookURL(webhookReq.URL); err != nil {
+		return nil, err
+	}
+	for _, eventType := range webhookReq.EventTypes {
+		if !events.Type(eventType).IsKnown() {
+			return nil, models.NewErrorResponse(http.StatusBadRequest, fmt.Sprintf("unknown event type: %s", eventType))
+		}
+	}
+	if webhookReq.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhookReq.Secret = secret
+	} else if len(webhookReq.Secret) < minWebhookSecretLength || len(webhookReq.Secret) > maxWebhookSecretLength {
+		return nil, models.NewErrorResponse(http.StatusBadRequest,
+			fmt.Sprintf("invalid secret, must be from %d to %d characters long", minWebhookSecretLength, maxWebhookSecretLength))
+	}
+	if err := s.checkResponsible(ctx, organizationId); err != nil {
+		return nil, err
+	}
+	return s.Repo.CreateWebhook(ctx, organizationId, webhookReq)
+}
+
+// DeleteWebhook удаляет вебхук организации.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, organizationId, webhookId string) error {
+	if err := s.checkResponsible(ctx, organizationId); err != nil {
+		return err
+	}
+	if err := s.Repo.DeleteWebhook(ctx, organizationId, webhookId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.NewErrorResponse(http.StatusNotFound, "webhook not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// GetDeadLetters получает события, которые не удалось доставить на вебхук организации.
+func (s *WebhookService) GetDeadLetters(ctx context.Context, organizationId, webhookId, limitStr, offsetStr string) ([]models.WebhookDeadLetter, error) {
+	limit, offset, err := utils.ParseLimitOffset(limitStr, offsetStr)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest, err.Error())
+	}
+	if err = s.checkResponsible(ctx, organizationId); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetDeadLetters(ctx, organizationId, webhookId, limit, offset)
+}
+
+// checkResponsible проверяет, что вызывающий пользователь является ответственным организации.
+func (s *WebhookService) checkResponsible(ctx context.Context, organizationId string) error {
+	if _, err := callerUsername(ctx); err != nil {
+		return err
+	}
+	return authorize(ctx, s.policy, subjectFromContext(ctx), authz.OrganizationManage, authz.OrganizationResource(organizationId),
+		"you are not responsible for this organization")
+}
+
+// generateWebhookSecret генерирует случайный секрет вебхука.
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound возвращается, если в хранилище нет файла с указанным ключом.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore - интерфейс хранилища содержимого файлов. Ключ - относительный путь из сегментов,
+// разделённых косой чертой, например "tenders/{tenderId}/{attachmentId}".
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalBlobStore - реализация BlobStore, хранящая файлы в каталоге локальной файловой системы.
+type LocalBlobStore struct {
+	Root string
+}
+
+// NewLocalBlobStore создаёт новый экземпляр LocalBlobStore и при необходимости создаёт каталог root.
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+	return &LocalBlobStore{Root: root}, nil
+}
+
+// path возвращает путь к файлу по ключу. Ключи, выходящие за пределы каталога хранилища, отклоняются.
+func (s *LocalBlobStore) path(key string) (string, error) {
+	name := filepath.FromSlash(key)
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(s.Root, name), nil
+}
+
+// Put сохраняет содержимое под ключом key. Содержимое записывается во временный файл, который переименовывается
+// только после успешной записи, поэтому при ошибке чтения content в хранилище не остаётся частично записанного файла.
+func (s *LocalBlobStore) Put(ctx context.Context, key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	if err = ctx.Err(); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// Open открывает содержимое, сохранённое под ключом key. Если файла нет, возвращается ErrBlobNotFound.
+func (s *LocalBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return file, err
+}
+
+// Delete удаляет содержимое, сохранённое под ключом key. Отсутствие файла ошибкой не считается.
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
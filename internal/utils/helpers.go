@@ -6,10 +6,16 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/pagination"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -53,6 +59,34 @@ func ParseLimitOffset(limitStr, offsetStr string) (int, int, error) {
 	return limit, offset, nil
 }
 
+// ParsePage разбирает параметры страницы списка list с сортировкой sort. Если передан параметр cursor,
+// страница выбирается по курсору: пустой cursor означает первую страницу, а offset не допускается.
+// Иначе используются limit и offset, как в ParseLimitOffset.
+func ParsePage(query url.Values, signer *pagination.Signer, list, sort string) (models.Page, error) {
+	if !query.Has("cursor") {
+		limit, offset, err := ParseLimitOffset(query.Get("limit"), query.Get("offset"))
+		return models.Page{Limit: limit, Offset: offset}, err
+	}
+
+	if query.Get("offset") != "" {
+		return models.Page{}, fmt.Errorf("offset parameter cannot be combined with cursor")
+	}
+	limit, _, err := ParseLimitOffset(query.Get("limit"), "")
+	if err != nil {
+		return models.Page{}, err
+	}
+
+	page := models.Page{Limit: limit, Cursor: true}
+	if token := query.Get("cursor"); token != "" {
+		cursor, err := signer.Decode(token, list, sort)
+		if err != nil {
+			return models.Page{}, fmt.Errorf("invalid cursor parameter, it must be a nextCursor returned for this list")
+		}
+		page.After = cursor.After
+	}
+	return page, nil
+}
+
 // CheckOrganizationExists проверяет, существует ли организация
 func CheckOrganizationExists(ctx context.Context, dbPool *pgxpool.Pool, organizationId string) (bool, error) {
 	var exists bool
@@ -75,23 +109,6 @@ func CheckUserInAnyOrganization(ctx context.Context, dbPool *pgxpool.Pool, userI
 	return exists, nil
 }
 
-// CheckUserResponsibleForOrganization проверяет, является ли пользователь ответственным за создание тендеров для организации
-func CheckUserResponsibleForOrganization(ctx context.Context, dbPool *pgxpool.Pool, user, organizationId string) (bool, error) {
-	var isResponsible bool
-	query := `
-		SELECT EXISTS(
-			SELECT 1 
-			FROM organization_responsible orr
-			JOIN employee e ON orr.user_id = e.id
-			WHERE e.username = $1 AND orr.organization_id = $2
-		)`
-	err := dbPool.QueryRow(ctx, query, user, organizationId).Scan(&isResponsible)
-	if err != nil {
-		return false, err
-	}
-	return isResponsible, nil
-}
-
 // CheckUserExists проверяет, существует ли пользователь с указанным username
 func CheckUserExists(ctx context.Context, dbPool *pgxpool.Pool, username string) (bool, error) {
 	var exists bool
@@ -114,16 +131,6 @@ func CheckUserExistsById(ctx context.Context, dbPool *pgxpool.Pool, userId strin
 	return exists, nil
 }
 
-// ContainsTender - функция для проверки перехода у тендеров
-func ContainsTender(validTransitions []models.TenderStatus, newStatus models.TenderStatus) bool {
-	for _, validStatus := range validTransitions {
-		if validStatus == newStatus {
-			return true
-		}
-	}
-	return false
-}
-
 // CheckTenderExists проверяет, существует ли тендер
 func CheckTenderExists(ctx context.Context, dbPool *pgxpool.Pool, tenderId string) (bool, error) {
 	var exists bool
@@ -132,48 +139,6 @@ func CheckTenderExists(ctx context.Context, dbPool *pgxpool.Pool, tenderId strin
 	return exists, err
 }
 
-// CheckUserAuthorized проверяет, что пользователь имеет право просматривать предложения по этому тендеру
-func CheckUserAuthorized(ctx context.Context, dbPool *pgxpool.Pool, username, tenderId string) (bool, error) {
-	var isAuthorized bool
-	query := `
-		SELECT EXISTS(
-			SELECT 1
-			FROM tender
-			WHERE id = $1 AND creator_username = $2
-			OR EXISTS(
-				SELECT 1 FROM organization_responsible
-				WHERE organization_id = tender.organization_id
-				AND user_id = (SELECT id FROM employee WHERE username = $2)
-			)
-		)`
-	err := dbPool.QueryRow(ctx, query, tenderId, username).Scan(&isAuthorized)
-	return isAuthorized, err
-}
-
-// CheckUserAuthorizedForBid проверяет, что пользователь имеет право просматривать заявку (bid) по этому bidId.
-func CheckUserAuthorizedForBid(ctx context.Context, dbPool *pgxpool.Pool, username, bidId string) (bool, error) {
-	var isAuthorized bool
-	query := `
-		SELECT EXISTS(
-			SELECT 1
-			FROM bid
-			WHERE id = $1
-			AND author_id = (SELECT id FROM employee WHERE username = $2)
-		)`
-	err := dbPool.QueryRow(ctx, query, bidId, username).Scan(&isAuthorized)
-	return isAuthorized, err
-}
-
-// ContainsBid - функция для проверки перехода у предложений
-func ContainsBid(validStatuses []models.BidStatus, newStatus models.BidStatus) bool {
-	for _, validStatus := range validStatuses {
-		if validStatus == newStatus {
-			return true
-		}
-	}
-	return false
-}
-
 // CheckBidExists проверяет существование предложения по его ID
 func CheckBidExists(ctx context.Context, dbPool *pgxpool.Pool, bidId string) (bool, error) {
 	var exists bool
@@ -188,7 +153,8 @@ func CheckBidExists(ctx context.Context, dbPool *pgxpool.Pool, bidId string) (bo
 // GetTenderById получает тендер по ID.
 func GetTenderById(ctx context.Context, dbPool *pgxpool.Pool, tenderId string) (*models.Tender, error) {
 	var tender models.Tender
-	query := `SELECT id, name, description, service_type, status, organization_id, version, created_at, creator_username
+	query := `SELECT id, name, description, service_type, status, organization_id, version, created_at, creator_username,
+	                 submission_deadline, decision_deadline, sealed, bids_opening_at, bids_opened_at
 	          FROM tender WHERE id = $1`
 	err := dbPool.QueryRow(ctx, query, tenderId).Scan(
 		&tender.ID,
@@ -200,6 +166,11 @@ func GetTenderById(ctx context.Context, dbPool *pgxpool.Pool, tenderId string) (
 		&tender.Version,
 		&tender.CreatedAt,
 		&tender.CreatorUsername,
+		&tender.SubmissionDeadline,
+		&tender.DecisionDeadline,
+		&tender.Sealed,
+		&tender.BidsOpeningAt,
+		&tender.BidsOpenedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -210,7 +181,8 @@ func GetTenderById(ctx context.Context, dbPool *pgxpool.Pool, tenderId string) (
 // GetBidById получает заявку (bid) по ID.
 func GetBidById(ctx context.Context, dbPool *pgxpool.Pool, bidId string) (*models.Bid, error) {
 	var bid models.Bid
-	query := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
+	query := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at,
+	                 price_amount, currency, timeframe_days, warranty_terms, lot_prices
 	          FROM bid WHERE id = $1`
 	err := dbPool.QueryRow(ctx, query, bidId).Scan(
 		&bid.ID,
@@ -222,9 +194,237 @@ func GetBidById(ctx context.Context, dbPool *pgxpool.Pool, bidId string) (*model
 		&bid.AuthorId,
 		&bid.Version,
 		&bid.CreatedAt,
+		&bid.PriceAmount,
+		&bid.Currency,
+		&bid.TimeframeDays,
+		&bid.WarrantyTerms,
+		&bid.LotPrices,
 	)
 	if err != nil {
 		return nil, err
 	}
 	return &bid, nil
 }
+
+// GetUserIdByUsername получает id пользователя по его username.
+func GetUserIdByUsername(ctx context.Context, dbPool *pgxpool.Pool, username string) (string, error) {
+	var userId string
+	query := `SELECT id FROM employee WHERE username = $1`
+	err := dbPool.QueryRow(ctx, query, username).Scan(&userId)
+	if err != nil {
+		return "", err
+	}
+	return userId, nil
+}
+
+// CheckUserDecidedOnBid проверяет, принимал ли пользователь уже решение по предложению.
+func CheckUserDecidedOnBid(ctx context.Context, dbPool *pgxpool.Pool, userId, bidId string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM bid_decision WHERE bid_id = $1 AND user_id = $2)`
+	err := dbPool.QueryRow(ctx, query, bidId, userId).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetUserCredentials получает id пользователя и хэш его пароля по username.
+func GetUserCredentials(ctx context.Context, dbPool *pgxpool.Pool, username string) (string, *string, error) {
+	var userId string
+	var passwordHash *string
+	query := `SELECT id, password_hash FROM employee WHERE username = $1`
+	err := dbPool.QueryRow(ctx, query, username).Scan(&userId, &passwordHash)
+	if err != nil {
+		return "", nil, err
+	}
+	return userId, passwordHash, nil
+}
+
+// SendError отправляет ошибку в формате JSON вместе с текущей версией объекта, если она известна.
+func SendError(w http.ResponseWriter, errorResponse *models.ErrorResponse) {
+	if errorResponse.CurrentVersion != nil {
+		w.Header().Set("ETag", ETag(*errorResponse.CurrentVersion))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errorResponse.StatusCode)
+
+	if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
+		log.Println(err)
+	}
+}
+
+// ETag формирует значение заголовка ETag по версии объекта.
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ParseExpectedVersion получает ожидаемую клиентом версию объекта из заголовка If-Match
+// или параметра expectedVersion. Возвращает 0, если версия не указана, и признак того,
+// что она была передана в заголовке If-Match.
+func ParseExpectedVersion(r *http.Request) (int, bool, error) {
+	if ifMatch := strings.TrimSpace(r.Header.Get("If-Match")); ifMatch != "" && ifMatch != "*" {
+		version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+		if err != nil || version <= 0 {
+			return 0, true, fmt.Errorf("invalid If-Match header, must be a tender or bid version")
+		}
+		return version, true, nil
+	}
+
+	if versionStr := r.URL.Query().Get("expectedVersion"); versionStr != "" {
+		version, err := strconv.Atoi(versionStr)
+		if err != nil || version <= 0 {
+			return 0, false, fmt.Errorf("invalid expectedVersion parameter, must be a positive integer")
+		}
+		return version, false, nil
+	}
+	return 0, false, nil
+}
+
+// ParseTenderFilter разбирает параметры сортировки и фильтрации списков тендеров:
+// sort, status, organizationId, createdAfter, createdBefore и service_type.
+func ParseTenderFilter(query url.Values) (models.TenderFilter, error) {
+	if query.Has("authorType") {
+		return models.TenderFilter{}, fmt.Errorf("authorType filter is not supported for tenders")
+	}
+	listFilter, err := parseListFilter(query, models.TenderSorts, models.TenderStatuses)
+	if err != nil {
+		return models.TenderFilter{}, err
+	}
+	return models.TenderFilter{ListFilter: listFilter, ServiceTypes: query["service_type"]}, nil
+}
+
+// ParseBidFilter разбирает параметры сортировки и фильтрации списка предложений: sort, status, organizationId,
+// createdAfter, createdBefore, authorType, currency, minPrice, maxPrice и maxTimeframeDays.
+func ParseBidFilter(query url.Values) (models.BidFilter, error) {
+	listFilter, err := parseListFilter(query, models.BidSorts, models.BidStatuses)
+	if err != nil {
+		return models.BidFilter{}, err
+	}
+	filter := models.BidFilter{
+		ListFilter:  listFilter,
+		AuthorTypes: query["authorType"],
+		Currency:    query.Get("currency"),
+	}
+	if err = checkAllowedValues("authorType", filter.AuthorTypes, models.BidAuthorTypes); err != nil {
+		return models.BidFilter{}, err
+	}
+
+	if filter.MinPrice, err = parsePriceParam(query, "minPrice"); err != nil {
+		return models.BidFilter{}, err
+	}
+	if filter.MaxPrice, err = parsePriceParam(query, "maxPrice"); err != nil {
+		return models.BidFilter{}, err
+	}
+
+	if str := query.Get("maxTimeframeDays"); str != "" {
+		days, err := strconv.Atoi(str)
+		if err != nil || days <= 0 {
+			return models.BidFilter{}, fmt.Errorf("invalid maxTimeframeDays parameter, must be a positive integer")
+		}
+		filter.MaxTimeframeDays = &days
+	}
+
+	return filter, nil
+}
+
+// parseListFilter разбирает общие параметры списков и проверяет сортировку и статусы по допустимым значениям.
+// Параметры status и organizationId можно повторять.
+func parseListFilter(query url.Values, sorts, statuses []string) (models.ListFilter, error) {
+	filter := models.ListFilter{
+		Sort:            query.Get("sort"),
+		Statuses:        query["status"],
+		OrganizationIDs: query["organizationId"],
+	}
+
+	if filter.Sort != "" {
+		if err := checkAllowedValues("sort", []string{filter.Sort}, sorts); err != nil {
+			return models.ListFilter{}, err
+		}
+	}
+	if err := checkAllowedValues("status", filter.Statuses, statuses); err != nil {
+		return models.ListFilter{}, err
+	}
+	for _, organizationId := range filter.OrganizationIDs {
+		if _, err := uuid.Parse(organizationId); err != nil {
+			return models.ListFilter{}, fmt.Errorf("invalid organizationId parameter, must be a UUID")
+		}
+	}
+
+	var err error
+	if filter.CreatedAfter, err = ParseTimeParam(query, "createdAfter"); err != nil {
+		return models.ListFilter{}, err
+	}
+	if filter.CreatedBefore, err = ParseTimeParam(query, "createdBefore"); err != nil {
+		return models.ListFilter{}, err
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && filter.CreatedAfter.After(*filter.CreatedBefore) {
+		return models.ListFilter{}, fmt.Errorf("createdAfter must not be later than createdBefore")
+	}
+	return filter, nil
+}
+
+// checkAllowedValues проверяет, что все значения параметра name входят в список допустимых.
+func checkAllowedValues(name string, values, allowed []string) error {
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			return fmt.Errorf("invalid %s parameter, must be one of: %s", name, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// ParseTenderSearch разбирает параметры поиска тендеров: q, status, organizationId, service_type,
+// createdFrom и createdTo в формате RFC 3339, budgetFrom, budgetTo и currency.
+// Параметры status, organizationId и service_type можно повторять.
+func ParseTenderSearch(query url.Values) (models.TenderSearch, error) {
+	search := models.TenderSearch{
+		Query:           strings.TrimSpace(query.Get("q")),
+		Statuses:        query["status"],
+		OrganizationIDs: query["organizationId"],
+		ServiceTypes:    query["service_type"],
+		Currency:        query.Get("currency"),
+	}
+
+	var err error
+	if search.CreatedFrom, err = ParseTimeParam(query, "createdFrom"); err != nil {
+		return models.TenderSearch{}, err
+	}
+	if search.CreatedTo, err = ParseTimeParam(query, "createdTo"); err != nil {
+		return models.TenderSearch{}, err
+	}
+	if search.BudgetFrom, err = parsePriceParam(query, "budgetFrom"); err != nil {
+		return models.TenderSearch{}, err
+	}
+	if search.BudgetTo, err = parsePriceParam(query, "budgetTo"); err != nil {
+		return models.TenderSearch{}, err
+	}
+
+	return search, nil
+}
+
+// ParseTimeParam разбирает необязательный параметр с датой в формате RFC 3339.
+func ParseTimeParam(query url.Values, name string) (*time.Time, error) {
+	str := query.Get(name)
+	if str == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s parameter, must be an RFC 3339 timestamp", name)
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// parsePriceParam разбирает необязательный неотрицательный параметр цены.
+func parsePriceParam(query url.Values, name string) (*float64, error) {
+	str := query.Get(name)
+	if str == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(str, 64)
+	if err != nil || price < 0 {
+		return nil, fmt.Errorf("invalid %s parameter, must be a non-negative number", name)
+	}
+	return &price, nil
+}
@@ -0,0 +1,46 @@
+package webhooks
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/models"
+)
+
+// newDeliveryClient создаёт HTTP-клиент для доставки событий. Клиент не подключается к локальным
+// и внутренним адресам: проверяется уже разрешённый адрес, поэтому запрет нельзя обойти именем,
+// указывающим на внутреннюю сеть. Клиент не следует перенаправлениям и не использует прокси.
+func newDeliveryClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: checkDeliveryAddress,
+	}
+	return &http.Client{
+		Timeout: deliveryTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: deliveryTimeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkDeliveryAddress запрещает подключение к адресу, на который нельзя отправлять вебхуки.
+func checkDeliveryAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !models.IsPublicWebhookIP(ip) {
+		return fmt.Errorf("webhook address %s is not allowed", host)
+	}
+	return nil
+}
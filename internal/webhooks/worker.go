@@ -0,0 +1,165 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/senyabanana/tender-service/internal/db"
+	"github.com/senyabanana/tender-service/internal/models"
+	"github.com/senyabanana/tender-service/internal/repository"
+)
+
+const (
+	// MaxAttempts - число попыток доставки, после которого событие переносится в таблицу недоставленных.
+	MaxAttempts = 8
+
+	// baseBackoff и maxBackoff - пауза перед второй попыткой и верхняя граница паузы между попытками.
+	// Пауза удваивается после каждой неудачной попытки.
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+
+	// deliveryTimeout - время ожидания ответа адреса вебхука.
+	deliveryTimeout = 10 * time.Second
+
+	// deliveryLease - время, на которое доставка резервируется за обработчиком на время отправки.
+	deliveryLease = time.Minute
+
+	// batchSize - максимальное число доставок, выбираемых за один запуск.
+	batchSize = 50
+)
+
+// Заголовки запроса доставки события.
+const (
+	SignatureHeader = "X-Webhook-Signature" // Подпись "sha256=<hex>" тела с меткой времени
+	TimestampHeader = "X-Webhook-Timestamp" // Время отправки в секундах Unix
+	EventHeader     = "X-Webhook-Event"     // Тип события
+	DeliveryHeader  = "X-Webhook-Delivery"  // Идентификатор события, одинаковый для всех попыток
+)
+
+// Worker периодически доставляет события из очереди на адреса вебхуков организаций.
+// Несколько реплик могут работать одновременно: доставки резервируются за обработчиком в базе данных.
+type Worker struct {
+	Repo     repository.WebhookRepository
+	Client   *http.Client
+	Interval time.Duration
+	Logger   *log.Logger
+	uow      *db.UnitOfWork
+}
+
+// NewWorker создаёт новый экземпляр Worker.
+func NewWorker(repo repository.WebhookRepository, uow *db.UnitOfWork, interval time.Duration, logger *log.Logger) *Worker {
+	return &Worker{
+		Repo:     repo,
+		Client:   newDeliveryClient(),
+		Interval: interval,
+		Logger:   logger,
+		uow:      uow,
+	}
+}
+
+// Run запускает обработчик и блокируется до отмены контекста.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.deliverPending(ctx)
+		}
+	}
+}
+
+// deliverPending отправляет доставки, время попытки которых наступило.
+func (w *Worker) deliverPending(ctx context.Context) {
+	now := time.Now().UTC()
+	deliveries, err := w.Repo.ClaimDeliveries(ctx, now, now.Add(deliveryLease), batchSize)
+	if err != nil {
+		w.Logger.Printf("webhooks: failed to claim deliveries: %v", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if err = w.deliver(ctx, delivery); err == nil {
+			err = w.Repo.CompleteDelivery(ctx, delivery.ID)
+		} else {
+			err = w.fail(ctx, delivery, err)
+		}
+		if err != nil {
+			w.Logger.Printf("webhooks: failed to save delivery %s: %v", delivery.ID, err)
+		}
+	}
+}
+
+// deliver отправляет событие на адрес вебхука. Успешной считается доставка с ответом 2xx.
+func (w *Worker) deliver(ctx context.Context, delivery models.WebhookDelivery) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Payload))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.EventID)
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Тело ответа не сохраняется: описание ошибки видно организации в списке недоставленных событий.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// fail назначает повторную попытку доставки или, если попытки исчерпаны, переносит её в таблицу недоставленных.
+func (w *Worker) fail(ctx context.Context, delivery models.WebhookDelivery, deliveryErr error) error {
+	if delivery.Attempts >= MaxAttempts {
+		w.Logger.Printf("webhooks: giving up delivery %s after %d attempts: %v", delivery.ID, delivery.Attempts, deliveryErr)
+		return w.uow.WithinTx(ctx, func(ctx context.Context) error {
+			return w.Repo.DeadLetterDelivery(ctx, delivery, deliveryErr.Error())
+		})
+	}
+	nextAttemptAt := time.Now().UTC().Add(Backoff(delivery.Attempts))
+	return w.Repo.RetryDelivery(ctx, delivery.ID, nextAttemptAt, deliveryErr.Error())
+}
+
+// Backoff возвращает паузу перед следующей попыткой после attempts неудачных попыток:
+// baseBackoff, удваиваемую после каждой попытки, но не больше maxBackoff.
+func Backoff(attempts int) time.Duration {
+	backoff := baseBackoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// Sign вычисляет подпись HMAC-SHA256 строки "<timestamp>.<payload>" секретом вебхука
+// в формате "sha256=<hex>". Получатель проверяет подпись тем же секретом и отклоняет
+// запросы со слишком старой меткой времени.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
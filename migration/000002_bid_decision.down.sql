@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bid_decision;
\ No newline at end of file
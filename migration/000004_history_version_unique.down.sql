@@ -0,0 +1,10 @@
+-- Уникальность версий снимается, и повторяющиеся версии, перенесённые при применении миграции,
+-- возвращаются из архивных таблиц в историю, так что откат не теряет записей.
+DROP INDEX IF EXISTS bid_history_bid_id_version_idx;
+DROP INDEX IF EXISTS tender_history_id_version_idx;
+
+INSERT INTO tender_history SELECT * FROM tender_history_duplicate;
+INSERT INTO bid_history SELECT * FROM bid_history_duplicate;
+
+DROP TABLE IF EXISTS bid_history_duplicate;
+DROP TABLE IF EXISTS tender_history_duplicate;
@@ -0,0 +1,42 @@
+-- Повторяющиеся версии в истории не удаляются, а переносятся в архивные таблицы *_history_duplicate:
+-- в истории остаётся последняя вставленная запись каждой версии, остальные сохраняются для разбора.
+-- Туда же переносятся записи с версией не ниже текущей версии тендера или предложения: прежние
+-- редактирование и откат сохраняли в историю и текущую версию, а следующее изменение сохраняет
+-- её снова и нарушило бы уникальность.
+CREATE TABLE IF NOT EXISTS tender_history_duplicate (LIKE tender_history);
+CREATE TABLE IF NOT EXISTS bid_history_duplicate (LIKE bid_history);
+
+WITH moved AS (
+    DELETE FROM tender_history h
+        USING tender t
+    WHERE h.id = t.id AND h.version >= t.version
+    RETURNING h.*
+)
+INSERT INTO tender_history_duplicate SELECT * FROM moved;
+
+WITH moved AS (
+    DELETE FROM bid_history h
+        USING bid b
+    WHERE h.bid_id = b.id AND h.version >= b.version
+    RETURNING h.*
+)
+INSERT INTO bid_history_duplicate SELECT * FROM moved;
+
+WITH moved AS (
+    DELETE FROM tender_history a
+        USING tender_history b
+    WHERE a.ctid < b.ctid AND a.id = b.id AND a.version = b.version
+    RETURNING a.*
+)
+INSERT INTO tender_history_duplicate SELECT * FROM moved;
+
+WITH moved AS (
+    DELETE FROM bid_history a
+        USING bid_history b
+    WHERE a.ctid < b.ctid AND a.bid_id = b.bid_id AND a.version = b.version
+    RETURNING a.*
+)
+INSERT INTO bid_history_duplicate SELECT * FROM moved;
+
+CREATE UNIQUE INDEX IF NOT EXISTS tender_history_id_version_idx ON tender_history (id, version);
+CREATE UNIQUE INDEX IF NOT EXISTS bid_history_bid_id_version_idx ON bid_history (bid_id, version);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_event (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_event_entity_idx ON audit_event (entity_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_event_organization_idx ON audit_event (organization_id, created_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS tender_published_deadline_idx;
+
+ALTER TABLE tender_history DROP COLUMN IF EXISTS decision_deadline;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS submission_deadline;
+
+ALTER TABLE tender DROP COLUMN IF EXISTS decision_deadline;
+ALTER TABLE tender DROP COLUMN IF EXISTS submission_deadline;
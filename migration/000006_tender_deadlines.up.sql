@@ -0,0 +1,7 @@
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMP;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS decision_deadline TIMESTAMP;
+
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMP;
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS decision_deadline TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS tender_published_deadline_idx ON tender (submission_deadline) WHERE status = 'Published';
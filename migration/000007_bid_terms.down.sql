@@ -0,0 +1,9 @@
+ALTER TABLE bid_history DROP COLUMN IF EXISTS warranty_terms;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS timeframe_days;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS currency;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS price_amount;
+
+ALTER TABLE bid DROP COLUMN IF EXISTS warranty_terms;
+ALTER TABLE bid DROP COLUMN IF EXISTS timeframe_days;
+ALTER TABLE bid DROP COLUMN IF EXISTS currency;
+ALTER TABLE bid DROP COLUMN IF EXISTS price_amount;
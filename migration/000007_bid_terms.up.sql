@@ -0,0 +1,9 @@
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS price_amount NUMERIC(18, 2) CHECK (price_amount >= 0);
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS timeframe_days INTEGER CHECK (timeframe_days > 0);
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS warranty_terms TEXT;
+
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS price_amount NUMERIC(18, 2);
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS timeframe_days INTEGER;
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS warranty_terms TEXT;
@@ -0,0 +1,6 @@
+ALTER TABLE bid_history DROP COLUMN IF EXISTS lot_prices;
+ALTER TABLE bid DROP COLUMN IF EXISTS lot_prices;
+
+ALTER TABLE tender_history DROP COLUMN IF EXISTS lots;
+
+DROP TABLE IF EXISTS tender_lot;
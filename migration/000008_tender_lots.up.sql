@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS tender_lot (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    quantity NUMERIC(18, 3) NOT NULL CHECK (quantity > 0),
+    unit VARCHAR(20) NOT NULL,
+    max_budget NUMERIC(18, 2) CHECK (max_budget >= 0),
+    currency VARCHAR(3),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS tender_lot_tender_id_idx ON tender_lot (tender_id);
+
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS lots JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS lot_prices JSONB;
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS lot_prices JSONB;
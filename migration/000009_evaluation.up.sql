@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS tender_criterion (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('price', 'timeframe', 'custom')),
+    weight NUMERIC(8, 3) NOT NULL CHECK (weight > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tender_criterion_builtin_kind_idx ON tender_criterion (tender_id, kind) WHERE kind <> 'custom';
+
+CREATE TABLE IF NOT EXISTS bid_score (
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    criterion_id UUID NOT NULL REFERENCES tender_criterion(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    score NUMERIC(6, 2) NOT NULL CHECK (score >= 0 AND score <= 100),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (bid_id, criterion_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS bid_score_criterion_id_idx ON bid_score (criterion_id);
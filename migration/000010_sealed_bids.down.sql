@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS tender_sealed_unopened_idx;
+
+ALTER TABLE tender_history DROP COLUMN IF EXISTS bids_opened_at;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS bids_opening_at;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS sealed;
+
+ALTER TABLE tender DROP COLUMN IF EXISTS bids_opened_at;
+ALTER TABLE tender DROP COLUMN IF EXISTS bids_opening_at;
+ALTER TABLE tender DROP COLUMN IF EXISTS sealed;
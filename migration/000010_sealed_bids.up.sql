@@ -0,0 +1,9 @@
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS bids_opening_at TIMESTAMP;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS bids_opened_at TIMESTAMP;
+
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS bids_opening_at TIMESTAMP;
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS bids_opened_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS tender_sealed_unopened_idx ON tender (bids_opening_at) WHERE sealed AND bids_opened_at IS NULL;
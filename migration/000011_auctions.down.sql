@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS auction_rebid;
+DROP TABLE IF EXISTS auction_round;
+DROP TABLE IF EXISTS tender_auction;
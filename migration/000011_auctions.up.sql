@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS tender_auction (
+    tender_id UUID PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    winner_bid_id UUID REFERENCES bid(id) ON DELETE SET NULL,
+    finished_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS auction_round (
+    tender_id UUID NOT NULL REFERENCES tender_auction(tender_id) ON DELETE CASCADE,
+    number INT NOT NULL CHECK (number > 0),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL CHECK (ends_at > starts_at),
+    closed_at TIMESTAMP,
+    PRIMARY KEY (tender_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS auction_round_open_idx ON auction_round (ends_at) WHERE closed_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS auction_rebid (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    tender_id UUID NOT NULL,
+    round_number INT NOT NULL,
+    previous_price NUMERIC(18, 2) NOT NULL,
+    price_amount NUMERIC(18, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tender_id, round_number) REFERENCES auction_round(tender_id, number) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS auction_rebid_bid_id_idx ON auction_rebid (bid_id, created_at);
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS webhook (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    secret VARCHAR(128) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhook_organization_id_idx ON webhook (organization_id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_next_attempt_idx ON webhook_delivery (next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS webhook_dead_letter (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    failed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhook_dead_letter_webhook_id_idx ON webhook_dead_letter (webhook_id, failed_at);
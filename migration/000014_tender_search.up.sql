@@ -0,0 +1,9 @@
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS tender_search_vector_idx ON tender USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS tender_created_at_idx ON tender (created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE bid_history DROP COLUMN IF EXISTS attachments;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS attachments;
+
+DROP TABLE IF EXISTS bid_attachment;
+DROP TABLE IF EXISTS tender_attachment;
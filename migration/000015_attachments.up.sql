@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS tender_attachment (
+    id UUID PRIMARY KEY,
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    checksum CHAR(64) NOT NULL,
+    uploaded_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS tender_attachment_tender_id_idx ON tender_attachment (tender_id);
+
+CREATE TABLE IF NOT EXISTS bid_attachment (
+    id UUID PRIMARY KEY,
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    checksum CHAR(64) NOT NULL,
+    uploaded_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS bid_attachment_bid_id_idx ON bid_attachment (bid_id);
+
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS attachments JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS attachments JSONB NOT NULL DEFAULT '[]';
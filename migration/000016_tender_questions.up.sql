@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS tender_question (
+    id UUID PRIMARY KEY,
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    asker_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    answer TEXT,
+    answered_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    answered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS tender_question_tender_id_idx ON tender_question (tender_id, created_at, id);
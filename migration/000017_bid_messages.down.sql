@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bid_message_read;
+DROP TABLE IF EXISTS bid_message;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS bid_message (
+    id UUID PRIMARY KEY,
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    author_side VARCHAR(20) NOT NULL CHECK (author_side IN ('bid', 'tender')),
+    bid_version INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS bid_message_bid_id_idx ON bid_message (bid_id, created_at, id);
+
+CREATE TABLE IF NOT EXISTS bid_message_read (
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    last_read_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (bid_id, user_id)
+);
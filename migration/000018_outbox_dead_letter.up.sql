@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS outbox_dead_letter (
+    id BIGINT PRIMARY KEY,
+    idempotency_key UUID NOT NULL UNIQUE,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    failed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);